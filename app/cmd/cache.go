@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+	"gohub/pkg/cache"
+	"gohub/pkg/console"
+
+	"github.com/spf13/cobra"
+)
+
+var CmdCache = &cobra.Command{
+	Use:   "cache",
+	Short: "Cache management",
+}
+
+var CmdCacheClear = &cobra.Command{
+	Use:   "clear",
+	Short: "Clear all cache items of this application",
+	Run:   runCacheClear,
+	Args:  cobra.NoArgs,
+}
+
+var CmdCacheForget = &cobra.Command{
+	Use:   "forget",
+	Short: "Delete a cache item by key",
+	Run:   runCacheForget,
+	Args:  cobra.ExactArgs(1), // 只允许且必须传 1 个参数
+}
+
+func init() {
+	// 注册 cache 命令的子命令
+	CmdCache.AddCommand(
+		CmdCacheClear,
+		CmdCacheForget,
+	)
+}
+
+func runCacheClear(cmd *cobra.Command, args []string) {
+	ensureSharedCacheStore()
+	// 只清除 app.name 前缀下的缓存, 不影响同一数据库中的其他数据
+	console.ExitIf(cache.Flush())
+	console.Success("Cache cleared.")
+}
+
+func runCacheForget(cmd *cobra.Command, args []string) {
+	ensureSharedCacheStore()
+	console.ExitIf(cache.Forget(args[0]))
+	console.Success(fmt.Sprintf("Deleted key [%s] from cache.", args[0]))
+}
+
+// ensureSharedCacheStore 内存缓存只存在于各自的进程中, 命令行无法操作正在运行的服务的缓存
+func ensureSharedCacheStore() {
+	if _, ok := cache.Cache.Store.(*cache.MemoryStore); ok {
+		console.Exit("cache.store is memory, the cache lives inside each running process and cannot be cleared from the command line, restart the server instead.")
+	}
+}
@@ -0,0 +1,41 @@
+// Package cmd 存放程序的所有子命令
+package cmd
+
+import (
+	"gohub/pkg/helpers"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// Env 存储全局选项 --env 的值
+var Env string
+
+// SkipBootstrap 命令的 Annotations 中设置此键时, 运行前不初始化 DB、Redis 和缓存
+// 适用于代码生成器等不依赖这些服务的命令, 子命令继承父命令的设置
+const SkipBootstrap = "skip_bootstrap"
+
+// RegisterGlobalFlags 注册全局选项 (flag)
+func RegisterGlobalFlags(rootCmd *cobra.Command) {
+	rootCmd.PersistentFlags().StringVarP(&Env, "env", "e", "", "加载 .env 文件, 如 --env=testing 加载的是 .env.testing 文件")
+}
+
+// RegisterDefaultCmd 注册默认命令
+func RegisterDefaultCmd(rootCmd *cobra.Command, subCmd *cobra.Command) {
+	cmd, _, err := rootCmd.Find(os.Args[1:])
+	firstArg := helpers.FirstElement(os.Args[1:])
+	if err == nil && cmd.Use == rootCmd.Use && firstArg != "-h" && firstArg != "--help" {
+		args := append([]string{subCmd.Use}, os.Args[1:]...)
+		rootCmd.SetArgs(args)
+	}
+}
+
+// NeedsBootstrap 命令运行前是否需要初始化 DB、Redis 和缓存
+func NeedsBootstrap(command *cobra.Command) bool {
+	for c := command; c != nil; c = c.Parent() {
+		if _, ok := c.Annotations[SkipBootstrap]; ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"gohub/pkg/console"
+	"gohub/pkg/helpers"
+
+	"github.com/spf13/cobra"
+)
+
+var CmdKey = &cobra.Command{
+	Use:   "key",
+	Short: "Generate App Key, will print the generated Key",
+	Run:   runKeyGenerate,
+	Args:  cobra.NoArgs,
+
+	// 生成 Key 无需连接数据库和 Redis
+	Annotations: map[string]string{SkipBootstrap: "true"},
+}
+
+func runKeyGenerate(cmd *cobra.Command, args []string) {
+	console.Success("---")
+	console.Success("App Key:")
+	console.Success(helpers.RandomString(32))
+	console.Success("---")
+	console.Warning("please go to .env file to change the APP_KEY option")
+}
@@ -0,0 +1,48 @@
+package make
+
+import (
+	"fmt"
+	"gohub/pkg/console"
+	"gohub/pkg/file"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var CmdMakeAPIController = &cobra.Command{
+	Use:   "apicontroller",
+	Short: "Create api controller, example: make apicontroller v1/user",
+	Run:   runMakeAPIController,
+	Args:  cobra.ExactArgs(1), // 只允许且必须传 1 个参数
+}
+
+func runMakeAPIController(cmd *cobra.Command, args []string) {
+
+	// 处理参数, 要求附带 API 版本 (v1 或者 v2)
+	array := strings.Split(args[0], "/")
+	if len(array) != 2 {
+		console.Exit("api controller name format: v1/user")
+	}
+
+	// apiVersion 用来拼接目标路径, 同时也是控制器的包名
+	// name 用来生成 cmd.Model 实例
+	apiVersion, name := array[0], array[1]
+	if !apiVersionPattern.MatchString(apiVersion) {
+		console.Exit("api version must be like v1, v2")
+	}
+	model := makeModelFromString(name)
+	variables := map[string]string{"{{APIVersion}}": apiVersion}
+
+	// 新的 API 版本, 先创建该版本的基础控制器
+	dir := fmt.Sprintf("app/http/controllers/api/%s/", apiVersion)
+	if !file.Exists(dir + "base_api_controller.go") {
+		createFileFromStub(dir+"base_api_controller.go", "base_api_controller", model, variables)
+	}
+
+	// 基于模板创建文件 (做好变量替换)
+	createFileFromStub(dir+model.TableName+"_controller.go", "apicontroller", model, variables)
+}
+
+// apiVersionPattern API 版本的格式, 如 v1、v2
+var apiVersionPattern = regexp.MustCompile(`^v[0-9]+$`)
@@ -0,0 +1,29 @@
+package make
+
+import (
+	"fmt"
+	"gohub/pkg/app"
+	"gohub/pkg/console"
+
+	"github.com/spf13/cobra"
+)
+
+var CmdMakeMigration = &cobra.Command{
+	Use:   "migration",
+	Short: "Create a migration file, example: make migration add_users_table user",
+	Run:   runMakeMigration,
+	Args:  cobra.ExactArgs(2), // 只允许且必须传 2 个参数
+}
+
+func runMakeMigration(cmd *cobra.Command, args []string) {
+
+	// 日期格式化, 作为迁移文件的时间戳前缀, 迁移按此顺序执行
+	timeStr := app.TimenowInTimezone().Format("2006_01_02_150405")
+
+	// 第一个参数是迁移名称, 第二个参数是模型名称
+	model := makeModelFromString(args[1])
+	fileName := timeStr + "_" + args[0]
+	filePath := fmt.Sprintf("database/migrations/%s.go", fileName)
+	createFileFromStub(filePath, "migration", model, map[string]string{"{{FileName}}": fileName})
+	console.Success("Migration file created, after modify it, use `migrate up` to migrate database.")
+}
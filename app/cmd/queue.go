@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"gohub/pkg/config"
+	"gohub/pkg/console"
+	"gohub/pkg/queue"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/spf13/cobra"
+)
+
+var CmdQueueWork = &cobra.Command{
+	Use:   "queue:work",
+	Short: "Start processing jobs on the queue",
+	Run:   runQueueWork,
+	Args:  cobra.NoArgs,
+}
+
+var CmdQueueFailed = &cobra.Command{
+	Use:   "queue:failed",
+	Short: "List all of the failed queue jobs",
+	Run:   runQueueFailed,
+	Args:  cobra.NoArgs,
+}
+
+var CmdQueueRetry = &cobra.Command{
+	Use:   "queue:retry",
+	Short: "Retry a failed queue job, use 'all' to retry all failed jobs",
+	Run:   runQueueRetry,
+	Args:  cobra.ExactArgs(1), // 只允许且必须传 1 个参数
+}
+
+// queueNames 要处理的队列, 逗号分隔, 排在前面的优先
+var queueNames string
+
+// queueWorkers 并发执行任务的 Worker 数量
+var queueWorkers int
+
+func init() {
+	CmdQueueWork.Flags().StringVarP(&queueNames, "queue", "q", "", "要处理的队列, 多个以逗号分隔, 排在前面的优先, 默认为 queue.default")
+	CmdQueueWork.Flags().IntVarP(&queueWorkers, "workers", "w", 1, "并发执行任务的 Worker 数量")
+}
+
+func runQueueWork(cmd *cobra.Command, args []string) {
+
+	if config.GetString("queue.connection") == "sync" {
+		console.Warning("queue.connection is sync, jobs are handled when dispatched, set QUEUE_CONNECTION=redis to use workers.")
+	}
+
+	queues := []string{}
+	for _, name := range strings.Split(queueNames, ",") {
+		if name = strings.TrimSpace(name); len(name) > 0 {
+			queues = append(queues, name)
+		}
+	}
+	if len(queues) == 0 {
+		queues = []string{config.GetString("queue.default")}
+	}
+
+	// 收到退出信号时, 等待正在执行的任务完成后再退出
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	console.Success(fmt.Sprintf("Processing jobs from [%s] with %d worker(s).", strings.Join(queues, ","), queueWorkers))
+
+	var wg sync.WaitGroup
+	for i := 0; i < queueWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			queue.Work(ctx, queues)
+		}()
+	}
+	wg.Wait()
+
+	console.Success("Workers stopped.")
+}
+
+func runQueueFailed(cmd *cobra.Command, args []string) {
+	failedJobs, err := queue.Failed()
+	console.ExitIf(err)
+
+	if len(failedJobs) == 0 {
+		console.Success("No failed jobs.")
+		return
+	}
+
+	for _, job := range failedJobs {
+		console.Warning(fmt.Sprintf("%s  %s  %s@%s  attempts:%d",
+			job.FailedAt.Format("2006-01-02 15:04:05"), job.ID, job.Name, job.Queue, job.Attempts))
+		fmt.Println("    " + job.Error)
+	}
+}
+
+func runQueueRetry(cmd *cobra.Command, args []string) {
+	if args[0] == "all" {
+		count, err := queue.RetryAll()
+		console.ExitIf(err)
+		console.Success(fmt.Sprintf("%d failed job(s) pushed back onto the queue.", count))
+		return
+	}
+
+	console.ExitIf(queue.Retry(args[0]))
+	console.Success("Failed job [" + args[0] + "] pushed back onto the queue.")
+}
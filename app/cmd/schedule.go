@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"gohub/app/schedules"
+	"gohub/pkg/app"
+	"gohub/pkg/config"
+	"gohub/pkg/console"
+	"gohub/pkg/schedule"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+)
+
+var CmdScheduleRun = &cobra.Command{
+	Use:   "schedule:run",
+	Short: "Run the scheduled tasks, tasks are executed once per tick across all replicas",
+	Run:   runScheduleRun,
+	Args:  cobra.NoArgs,
+}
+
+var CmdScheduleList = &cobra.Command{
+	Use:   "schedule:list",
+	Short: "List the scheduled tasks and their next run time",
+	Run:   runScheduleList,
+	Args:  cobra.NoArgs,
+}
+
+func runScheduleRun(cmd *cobra.Command, args []string) {
+	schedules.Initialize()
+
+	// 收到退出信号时, 等待正在执行的任务完成后再退出
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	console.Success(fmt.Sprintf("Running %d scheduled task(s), timezone %s.", len(schedule.Tasks()), config.GetString("app.timezone")))
+	schedule.Run(ctx)
+	console.Success("Scheduler stopped.")
+}
+
+func runScheduleList(cmd *cobra.Command, args []string) {
+	schedules.Initialize()
+
+	now := app.TimenowInTimezone()
+	for _, task := range schedule.Tasks() {
+		console.Success(fmt.Sprintf("%-15s %-25s next run at %s",
+			task.Spec, task.Name, task.Next(now).Format("2006-01-02 15:04:05 MST")))
+	}
+}
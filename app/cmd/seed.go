@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"gohub/database/seeders"
+	"gohub/pkg/console"
+	"gohub/pkg/seed"
+
+	"github.com/spf13/cobra"
+)
+
+var CmdDBSeed = &cobra.Command{
+	Use:   "seed",
+	Short: "Insert fake data to the database",
+	Run:   runSeeders,
+	Args:  cobra.MaximumNArgs(1), // 只允许 1 个参数
+}
+
+func runSeeders(cmd *cobra.Command, args []string) {
+	seeders.Initialize()
+	if len(args) > 0 {
+		// 有传参数的情况
+		name := args[0]
+		seed.RunSeeder(name)
+		console.Success("Run seeder " + name)
+	} else {
+		// 默认运行全部 Seeder
+		seed.RunAll()
+		console.Success("Done seeding.")
+	}
+}
@@ -0,0 +1,97 @@
+package auth
+
+import (
+	v1 "gohub/app/http/controllers/api/v1"
+	"gohub/app/requests"
+	"gohub/pkg/auth"
+	"gohub/pkg/captcha"
+	"gohub/pkg/jwt"
+	"gohub/pkg/response"
+	"gohub/pkg/verifycode"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LoginController 用户控制器
+type LoginController struct {
+	v1.BaseApiController
+}
+
+// LoginByPhone 手机登录
+func (lc *LoginController) LoginByPhone(c *gin.Context) {
+
+	// 1. 验证表单
+	request := requests.LoginByPhoneRequest{}
+	if ok := requests.Validate(c, &request, requests.LoginByPhone); !ok {
+		return
+	}
+
+	// 2. 尝试登录
+	user, err := auth.LoginByPhone(request.Phone)
+	if err != nil {
+		// 失败, 显示错误提示
+		response.Error(c, err, "账号不存在")
+	} else {
+		// 登录成功, 作废验证码
+		verifycode.NewVerifyCode().Consume(request.Phone)
+		token := jwt.NewJWT().IssueToken(user.GetStringID(), user.Name)
+
+		response.JSON(c, gin.H{
+			"token": token,
+		})
+	}
+}
+
+// LoginByPassword 多种方法登录, 支持手机号、email 和用户名
+func (lc *LoginController) LoginByPassword(c *gin.Context) {
+
+	// 1. 验证表单
+	request := requests.LoginByPasswordRequest{}
+	if ok := requests.Validate(c, &request, requests.LoginByPassword); !ok {
+		return
+	}
+
+	// 2. 表单验证通过即作废图片验证码, 无论密码是否正确
+	// 否则一个图片验证码可以用来无限次尝试密码
+	captcha.NewCaptcha().ConsumeCaptcha(request.CaptchaID)
+
+	// 3. 尝试登录
+	user, err := auth.Attempt(request.LoginID, request.Password)
+	if err != nil {
+		// 失败, 显示错误提示
+		response.Unauthorized(c, "账号不存在或密码错误")
+	} else {
+		token := jwt.NewJWT().IssueToken(user.GetStringID(), user.Name)
+		response.JSON(c, gin.H{
+			"token": token,
+		})
+	}
+}
+
+// RefreshToken 刷新 Access Token
+func (lc *LoginController) RefreshToken(c *gin.Context) {
+
+	// 1. 解析令牌, 已过期但仍在可刷新时间内的令牌允许刷新
+	j := jwt.NewJWT()
+	claims, err := j.ParserRefreshToken(c)
+	if err != nil {
+		response.Error(c, err, "令牌刷新失败")
+		return
+	}
+
+	// 2. 用户已被删除, 或令牌签发于重置密码之前, 均不允许刷新
+	if _, err := auth.GetUserByClaims(claims); err != nil {
+		response.Unauthorized(c, err.Error())
+		return
+	}
+
+	// 3. 签发新的令牌
+	token, err := j.RefreshClaims(claims)
+	if err != nil {
+		response.Error(c, err, "令牌刷新失败")
+	} else {
+		response.JSON(c, gin.H{
+			"token": token,
+		})
+	}
+}
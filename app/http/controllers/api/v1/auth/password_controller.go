@@ -0,0 +1,87 @@
+package auth
+
+import (
+	v1 "gohub/app/http/controllers/api/v1"
+	"gohub/app/models/user"
+	"gohub/app/requests"
+	"gohub/pkg/app"
+	"gohub/pkg/logger"
+	"gohub/pkg/mail"
+	"gohub/pkg/queue"
+	"gohub/pkg/response"
+	"gohub/pkg/verifycode"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PasswordController 用户控制器
+type PasswordController struct {
+	v1.BaseApiController
+}
+
+// ResetByPhone 使用手机和验证码重置密码
+func (pc *PasswordController) ResetByPhone(c *gin.Context) {
+
+	// 1. 验证表单
+	request := requests.ResetByPhoneRequest{}
+	if ok := requests.Validate(c, &request, requests.ResetByPhone); !ok {
+		return
+	}
+
+	// 2. 更新密码
+	userModel := user.GetByPhone(request.Phone)
+	if userModel.ID == 0 {
+		response.Abort404(c)
+	} else {
+		resetPassword(c, &userModel, request.Password, request.Phone)
+	}
+}
+
+// ResetByEmail 使用 Email 和验证码重置密码
+func (pc *PasswordController) ResetByEmail(c *gin.Context) {
+
+	// 1. 验证表单
+	request := requests.ResetByEmailRequest{}
+	if ok := requests.Validate(c, &request, requests.ResetByEmail); !ok {
+		return
+	}
+
+	// 2. 更新密码
+	userModel := user.GetByEmail(request.Email)
+	if userModel.ID == 0 {
+		response.Abort404(c)
+	} else {
+		resetPassword(c, &userModel, request.Password, request.Email)
+	}
+}
+
+// resetPassword 保存新密码, 并让重置前签发的令牌全部失效
+// verifyKey 为接收验证码的手机号或邮箱, 重置成功后作废该验证码
+func resetPassword(c *gin.Context, userModel *user.User, password string, verifyKey string) {
+	now := app.TimenowInTimezone()
+	userModel.Password = password
+	userModel.PasswordChangedAt = &now
+
+	if rowsAffected := userModel.Save(); rowsAffected > 0 {
+		verifycode.NewVerifyCode().Consume(verifyKey)
+
+		// 绑定了邮箱的用户, 发送密码已重置的通知
+		// 通知发送失败不影响重置结果, 记录日志即可
+		if len(userModel.Email) > 0 {
+			err := queue.Dispatch(&mail.SendTemplateJob{
+				To:       []string{userModel.Email},
+				Template: "password_reset",
+				Data: map[string]interface{}{
+					"name":     userModel.Name,
+					"reset_at": now.Format("2006-01-02 15:04:05"),
+				},
+			})
+			if err != nil {
+				logger.ErrorString("重置密码", "发送通知邮件失败", err.Error())
+			}
+		}
+		response.Success(c)
+	} else {
+		response.Abort500(c, "重置密码失败, 请稍后尝试~")
+	}
+}
@@ -5,7 +5,11 @@ import (
 	v1 "gohub/app/http/controllers/api/v1"
 	"gohub/app/models/user"
 	"gohub/app/requests"
+	"gohub/pkg/logger"
+	"gohub/pkg/mail"
+	"gohub/pkg/queue"
 	"gohub/pkg/response"
+	"gohub/pkg/verifycode"
 
 	"github.com/gin-gonic/gin"
 )
@@ -44,3 +48,68 @@ func (sc *SignupController) IsPhoneExist(c *gin.Context) {
 		"exist": user.IsPhoneExist(request.Phone),
 	})
 }
+
+// SignupUsingPhone 使用手机和验证码进行注册
+func (sc *SignupController) SignupUsingPhone(c *gin.Context) {
+
+	// 1. 验证表单
+	request := requests.SignupUsingPhoneRequest{}
+	if ok := requests.Validate(c, &request, requests.SignupUsingPhone); !ok {
+		return
+	}
+
+	// 2. 验证成功, 创建数据
+	userModel := user.User{
+		Name:     request.Name,
+		Phone:    request.Phone,
+		Password: request.Password,
+	}
+	userModel.Create()
+
+	if userModel.ID > 0 {
+		// 注册成功, 作废验证码
+		verifycode.NewVerifyCode().Consume(request.Phone)
+		response.CreatedJSON(c, gin.H{
+			"data": userModel,
+		})
+	} else {
+		response.Abort500(c, "创建用户失败, 请稍后尝试~")
+	}
+}
+
+// SignupUsingEmail 使用 Email + 验证码进行注册
+func (sc *SignupController) SignupUsingEmail(c *gin.Context) {
+
+	// 1. 验证表单
+	request := requests.SignupUsingEmailRequest{}
+	if ok := requests.Validate(c, &request, requests.SignupUsingEmail); !ok {
+		return
+	}
+
+	// 2. 验证成功, 创建数据
+	userModel := user.User{
+		Name:     request.Name,
+		Email:    request.Email,
+		Password: request.Password,
+	}
+	userModel.Create()
+
+	if userModel.ID > 0 {
+		// 注册成功, 作废验证码并发送欢迎邮件
+		verifycode.NewVerifyCode().Consume(request.Email)
+		// 欢迎邮件发送失败不影响注册结果, 记录日志即可
+		err := queue.Dispatch(&mail.SendTemplateJob{
+			To:       []string{userModel.Email},
+			Template: "welcome",
+			Data:     map[string]interface{}{"name": userModel.Name},
+		})
+		if err != nil {
+			logger.ErrorString("注册", "发送欢迎邮件失败", err.Error())
+		}
+		response.CreatedJSON(c, gin.H{
+			"data": userModel,
+		})
+	} else {
+		response.Abort500(c, "创建用户失败, 请稍后尝试~")
+	}
+}
@@ -1,11 +1,14 @@
 package auth
 
 import (
+	"errors"
 	v1 "gohub/app/http/controllers/api/v1"
 	"gohub/app/requests"
 	"gohub/pkg/captcha"
 	"gohub/pkg/logger"
+	"gohub/pkg/mail"
 	"gohub/pkg/response"
+	"gohub/pkg/sms"
 	"gohub/pkg/verifycode"
 
 	"github.com/gin-gonic/gin"
@@ -39,11 +42,12 @@ func (vc *VerifyCodeController) SendUsingPhone(c *gin.Context) {
 	}
 
 	// 2. 发送 SMS
-	if ok := verifycode.NewVerifyCode().SendSms(request.Phone); !ok {
-		response.Abort500(c, "发送短信失败")
-	} else {
-		response.Success(c)
+	err := verifycode.NewVerifyCode().SendSms(request.Phone, c.ClientIP())
+	if err == nil {
+		// 发送成功, 作废图片验证码
+		captcha.NewCaptcha().ConsumeCaptcha(request.CaptchaID)
 	}
+	vc.respondSendResult(c, err, "发送短信失败")
 }
 
 // SendUsingEmail 发送 Email 验证码
@@ -56,11 +60,27 @@ func (vc *VerifyCodeController) SendUsingEmail(c *gin.Context) {
 	}
 
 	// 2. 发送邮件
-	err := verifycode.NewVerifyCode().SendEmail(request.Email)
+	err := verifycode.NewVerifyCode().SendEmail(request.Email, c.ClientIP())
+	if err == nil {
+		// 发送成功, 作废图片验证码
+		captcha.NewCaptcha().ConsumeCaptcha(request.CaptchaID)
+	}
+	vc.respondSendResult(c, err, "发送 Email 验证码失败")
+}
 
-	if err != nil {
-		response.Abort500(c, "发送 Email 验证码失败")
-	} else {
+// respondSendResult 根据验证码发送结果响应
+// 触发发送限制时响应 429, 其他错误 (如推送到队列失败) 响应 500
+// 验证码由队列异步发送, 服务商的发送失败由队列记录到日志和失败列表
+// queue.connection 为 sync 时会直接发送, 手机号或邮箱无法接收时响应 422
+func (vc *VerifyCodeController) respondSendResult(c *gin.Context, err error, failedMsg string) {
+	switch {
+	case err == nil:
 		response.Success(c)
+	case errors.Is(err, verifycode.ErrSendTooFrequent), errors.Is(err, verifycode.ErrDailyLimitExceeded):
+		response.Abort429(c, err.Error())
+	case errors.Is(err, sms.ErrInvalidPhone), errors.Is(err, mail.ErrRecipientRejected):
+		response.Error(c, err, failedMsg)
+	default:
+		response.Abort500(c, failedMsg)
 	}
 }
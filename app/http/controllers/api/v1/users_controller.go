@@ -0,0 +1,61 @@
+package v1
+
+import (
+	"errors"
+	"gohub/app/models/user"
+	"gohub/app/requests"
+	"gohub/pkg/auth"
+	"gohub/pkg/config"
+	"gohub/pkg/logger"
+	"gohub/pkg/paginator"
+	"gohub/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UsersController struct {
+	BaseApiController
+}
+
+// CurrentUser 当前登录用户信息
+func (ctrl *UsersController) CurrentUser(c *gin.Context) {
+	userModel := auth.CurrentUser(c)
+	response.Data(c, userModel)
+}
+
+// Index 所有用户
+func (ctrl *UsersController) Index(c *gin.Context) {
+	request := requests.PaginationRequest{}
+	if ok := requests.Validate(c, &request, requests.Pagination); !ok {
+		return
+	}
+
+	// 携带 cursor 参数时使用游标分页, 第一页传空值即可, 如 /v1/users?cursor=
+	if _, ok := c.GetQuery(config.Get("paging.url_query_cursor")); ok {
+		data, pager, err := user.CursorPaginate(c, 10)
+		if errors.Is(err, paginator.ErrInvalidCursor) {
+			// 游标与排序参数不匹配, 与请求验证的错误格式一致
+			response.ValidationError(c, map[string][]string{
+				"cursor": {err.Error()},
+			})
+			return
+		}
+		if err != nil {
+			// 数据库等内部错误只记录日志, 不返回给客户端
+			logger.LogIf(err)
+			response.Abort500(c, "获取用户列表失败, 请稍后尝试~")
+			return
+		}
+		response.JSON(c, gin.H{
+			"data":  data,
+			"pager": pager,
+		})
+		return
+	}
+
+	data, pager := user.Paginate(c, 10)
+	response.JSON(c, gin.H{
+		"data":  data,
+		"pager": pager,
+	})
+}
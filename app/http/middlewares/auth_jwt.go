@@ -0,0 +1,38 @@
+package middlewares
+
+import (
+	"gohub/pkg/auth"
+	"gohub/pkg/jwt"
+	"gohub/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuthJWT 强制使用 JWT 授权, 未登录或令牌无效时返回 401
+func AuthJWT() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		// 从标头 Authorization:Bearer xxxxx 中获取信息, 并验证 JWT 的准确性
+		claims, err := jwt.NewJWT().ParserToken(c)
+
+		// JWT 解析失败, 有错误发生
+		if err != nil {
+			response.Unauthorized(c, err.Error())
+			return
+		}
+
+		// JWT 解析成功, 设置用户信息
+		userModel, err := auth.GetUserByClaims(claims)
+		if err != nil {
+			response.Unauthorized(c, err.Error())
+			return
+		}
+
+		// 将用户信息存入 gin.context 里, 后续 auth 包将从这里拿到当前用户数据
+		c.Set("current_user_id", userModel.GetStringID())
+		c.Set("current_user_name", userModel.Name)
+		c.Set("current_user", userModel)
+
+		c.Next()
+	}
+}
@@ -0,0 +1,26 @@
+package middlewares
+
+import (
+	"gohub/pkg/jwt"
+	"gohub/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GuestJWT 强制使用游客身份访问, 已登录用户访问时返回 401
+func GuestJWT() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		if len(c.GetHeader("Authorization")) > 0 {
+
+			// 解析 token 成功, 说明登录成功了
+			_, err := jwt.NewJWT().ParserToken(c)
+			if err == nil {
+				response.Unauthorized(c, "请使用游客身份访问")
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
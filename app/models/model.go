@@ -1,7 +1,16 @@
 // 模型通用属性和方法
 package models
 
-import "time"
+import (
+	"gohub/pkg/cache"
+	"gohub/pkg/logger"
+	"gohub/pkg/str"
+	"reflect"
+	"time"
+
+	"github.com/spf13/cast"
+	"gorm.io/gorm"
+)
 
 // BaseModel 模型基类
 type BaseModel struct {
@@ -10,6 +19,99 @@ type BaseModel struct {
 
 // 时间戳模型
 type CommonTimestampsField struct {
-	CreateTime  time.Time `gorm:"column:created_at;index;" json:"created_at,omitempty"`
-	UpdatedTime time.Time `gorm:"column:updated_at;index;" json:"updated_at,omitempty"`
+	CreateTime  time.Time `gorm:"column:created_at;index;autoCreateTime;" json:"created_at,omitempty"`
+	UpdatedTime time.Time `gorm:"column:updated_at;index;autoUpdateTime;" json:"updated_at,omitempty"`
+}
+
+// GetStringID 获取 ID 的字符串格式
+func (a BaseModel) GetStringID() string {
+	return cast.ToString(a.ID)
+}
+
+// AfterSave GORM 的模型钩子, 模型创建和更新后清除模型的缓存标签
+// 模型如需定义自己的 AfterSave, 请在其中调用 BaseModel.AfterSave
+// 钩子在事务提交前执行, 提交后 RegisterCacheCallbacks 注册的回调会再清除一次
+func (a *BaseModel) AfterSave(tx *gorm.DB) (err error) {
+	flushCacheTags(a.cacheTags(tx.Statement.Table))
+	return
+}
+
+// AfterDelete GORM 的模型钩子, 模型删除后清除模型的缓存标签
+// 模型如需定义自己的 AfterDelete, 请在其中调用 BaseModel.AfterDelete
+func (a *BaseModel) AfterDelete(tx *gorm.DB) (err error) {
+	flushCacheTags(a.cacheTags(tx.Statement.Table))
+	return
+}
+
+// CacheTags 模型的缓存标签, 以 users 表 ID 为 42 的数据为例, 返回 users 和 user:42
+// 缓存模型数据时带上这些标签, 模型保存或删除后缓存会被自动清除:
+//
+//	cache.Tags(models.CacheTags("users", 42)...).Remember("user:42:profile", time.Hour, &profile, callback)
+//	cache.Tags("users").Remember("users:count", time.Hour, &count, callback)
+//
+// 在 database.DB.Transaction 中修改模型时, 清除发生在外层事务提交之前,
+// 如事务执行时间较长, 请在事务提交后再调用 cache.FlushTags 清除一次
+func CacheTags(table string, id uint64) []string {
+	tags := []string{table}
+	if id > 0 {
+		tags = append(tags, str.Singular(table)+":"+cast.ToString(id))
+	}
+	return tags
+}
+
+// cacheTagger 嵌入了 BaseModel 的模型
+type cacheTagger interface {
+	cacheTags(table string) []string
+}
+
+func (a BaseModel) cacheTags(table string) []string {
+	return CacheTags(table, a.ID)
+}
+
+// RegisterCacheCallbacks 注册 GORM 回调, 在事务提交后清除模型的缓存标签
+// AfterSave 和 AfterDelete 钩子在事务提交前执行, 此时并发的请求仍可能读到旧数据并写回缓存,
+// 提交后再清除一次, 保证缓存中不会留下旧数据
+func RegisterCacheCallbacks(db *gorm.DB) {
+	callback := db.Callback()
+	logger.LogIf(callback.Create().After("gorm:commit_or_rollback_transaction").Register("models:flush_cache_tags", flushCacheTagsAfterCommit))
+	logger.LogIf(callback.Update().After("gorm:commit_or_rollback_transaction").Register("models:flush_cache_tags", flushCacheTagsAfterCommit))
+	logger.LogIf(callback.Delete().After("gorm:commit_or_rollback_transaction").Register("models:flush_cache_tags", flushCacheTagsAfterCommit))
+}
+
+// flushCacheTagsAfterCommit 清除本次操作涉及的模型的缓存标签, 支持单个模型和模型切片
+func flushCacheTagsAfterCommit(db *gorm.DB) {
+	if db.Error != nil || db.Statement.Schema == nil {
+		return
+	}
+
+	tags := []string{}
+	rv := reflect.Indirect(db.Statement.ReflectValue)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			tags = append(tags, modelCacheTags(db.Statement.Table, rv.Index(i))...)
+		}
+	case reflect.Struct:
+		tags = modelCacheTags(db.Statement.Table, rv)
+	}
+	flushCacheTags(tags)
+}
+
+// modelCacheTags 读取单个模型的缓存标签, 未嵌入 BaseModel 的模型返回 nil
+func modelCacheTags(table string, rv reflect.Value) []string {
+	rv = reflect.Indirect(rv)
+	if !rv.IsValid() || !rv.CanInterface() {
+		return nil
+	}
+	if tagger, ok := rv.Interface().(cacheTagger); ok {
+		return tagger.cacheTags(table)
+	}
+	return nil
+}
+
+// flushCacheTags 清除缓存标签, 出错时只记录日志, 不影响数据库操作
+func flushCacheTags(tags []string) {
+	if err := cache.FlushTags(tags...); err != nil {
+		logger.ErrorString("缓存", "清除模型缓存标签失败", err.Error())
+	}
 }
@@ -0,0 +1,103 @@
+package models
+
+import (
+	"gohub/pkg/cache"
+	"gohub/pkg/logger"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+type widget struct {
+	BaseModel
+	Name string
+}
+
+// shadowWidget 定义了自己的 AfterSave 且未调用 BaseModel.AfterSave
+type shadowWidget struct {
+	BaseModel
+	Name string
+}
+
+func (w *shadowWidget) AfterSave(tx *gorm.DB) (err error) {
+	return
+}
+
+func TestMain(m *testing.M) {
+	logger.Logger = zap.NewNop()
+	cache.Cache = &cache.CacheService{Store: cache.NewMemoryStore()}
+	os.Exit(m.Run())
+}
+
+func openTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: gormlogger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(&widget{}, &shadowWidget{}); err != nil {
+		t.Fatal(err)
+	}
+	RegisterCacheCallbacks(db)
+	return db
+}
+
+func TestCacheTags(t *testing.T) {
+	tests := []struct {
+		table string
+		id    uint64
+		want  []string
+	}{
+		{"users", 42, []string{"users", "user:42"}},
+		{"topic_comments", 1, []string{"topic_comments", "topic_comment:1"}},
+		{"users", 0, []string{"users"}},
+	}
+	for _, tt := range tests {
+		if got := CacheTags(tt.table, tt.id); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("CacheTags(%q, %d) = %v, want %v", tt.table, tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestSaveAndDeleteFlushCacheTags(t *testing.T) {
+	db := openTestDB(t)
+
+	w := widget{Name: "a"}
+	db.Create(&w)
+
+	cache.Tags(CacheTags("widgets", w.ID)...).Set("widget:1:name", w.Name, time.Minute)
+	cache.Tags("widgets").Set("widgets:count", 1, time.Minute)
+
+	w.Name = "b"
+	db.Save(&w)
+	if cache.Has("widget:1:name") || cache.Has("widgets:count") {
+		t.Error("Save() did not flush the model cache tags")
+	}
+
+	cache.Tags(CacheTags("widgets", w.ID)...).Set("widget:1:name", w.Name, time.Minute)
+	db.Delete(&w)
+	if cache.Has("widget:1:name") {
+		t.Error("Delete() did not flush the model cache tags")
+	}
+}
+
+// TestFlushAfterCommit 模型覆盖了 AfterSave 钩子时, 提交后的回调仍会清除缓存
+func TestFlushAfterCommit(t *testing.T) {
+	db := openTestDB(t)
+
+	ws := []shadowWidget{{Name: "a"}, {Name: "b"}}
+	db.Create(&ws)
+
+	cache.Tags(CacheTags("shadow_widgets", ws[1].ID)...).Set("shadow_widget:2:name", "b", time.Minute)
+	ws[1].Name = "c"
+	db.Save(&ws[1])
+	if cache.Has("shadow_widget:2:name") {
+		t.Error("the after-commit callback did not flush the model cache tags")
+	}
+
+}
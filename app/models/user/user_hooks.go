@@ -0,0 +1,54 @@
+package user
+
+import (
+	"gohub/pkg/hash"
+
+	"github.com/spf13/cast"
+	"gorm.io/gorm"
+)
+
+// AfterFind GORM 的模型钩子, 记录查询出的密码, 保存时据此判断密码是否被修改
+func (userModel *User) AfterFind(tx *gorm.DB) (err error) {
+	userModel.originalPassword = userModel.Password
+	return
+}
+
+// BeforeSave GORM 的模型钩子, 在创建和更新模型前调用
+func (userModel *User) BeforeSave(tx *gorm.DB) (err error) {
+
+	// 1. Model(&user).Update("password", ...) 和 Updates(...) 的新值在 Dest 中, 而不是模型上
+	if tx.Statement.Dest != tx.Statement.Model {
+		if tx.Statement.Changed("Password") {
+			hashUpdatingPassword(tx.Statement)
+		}
+		return
+	}
+
+	// 2. Create 和 Save, 只在密码与查询时的值不同时加密, 避免重复哈希
+	if len(userModel.Password) > 0 && userModel.Password != userModel.originalPassword {
+		userModel.Password = hash.BcryptHash(userModel.Password)
+		userModel.originalPassword = userModel.Password
+	}
+	return
+}
+
+// hashUpdatingPassword 加密 Update/Updates 参数中的新密码
+func hashUpdatingPassword(stmt *gorm.Statement) {
+	switch value := stmt.Dest.(type) {
+	case map[string]interface{}:
+		// 字段名和列名都可以作为键, 原地替换, 避免同时存在明文和密文两个键
+		for _, key := range []string{"Password", "password"} {
+			if password := cast.ToString(value[key]); len(password) > 0 {
+				value[key] = hash.BcryptHash(password)
+			}
+		}
+	case User:
+		if len(value.Password) > 0 {
+			stmt.SetColumn("Password", hash.BcryptHash(value.Password))
+		}
+	case *User:
+		if len(value.Password) > 0 {
+			stmt.SetColumn("Password", hash.BcryptHash(value.Password))
+		}
+	}
+}
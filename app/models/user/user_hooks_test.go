@@ -0,0 +1,134 @@
+package user
+
+import (
+	"gohub/pkg/config"
+	"gohub/pkg/hash"
+	"gohub/pkg/logger"
+	"os"
+	"testing"
+
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+func TestMain(m *testing.M) {
+	logger.Logger = zap.NewNop()
+	config.Set("hash.bcrypt_cost", bcrypt.MinCost)
+	os.Exit(m.Run())
+}
+
+func openTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: gormlogger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(&User{}); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+// storedPassword 从数据库中读取 password 字段, 不经过模型钩子
+func storedPassword(t *testing.T, db *gorm.DB, id uint64) string {
+	var password string
+	if err := db.Table("users").Select("password").Where("id = ?", id).Scan(&password).Error; err != nil {
+		t.Fatal(err)
+	}
+	return password
+}
+
+func TestBeforeSaveHashesOnlyChangedPassword(t *testing.T) {
+	db := openTestDB(t)
+
+	userModel := User{Name: "summer", Password: "secret"}
+	if err := db.Create(&userModel).Error; err != nil {
+		t.Fatal(err)
+	}
+	created := storedPassword(t, db, userModel.ID)
+	if !hash.BcryptCheck("secret", created) {
+		t.Fatalf("password not hashed on create: %q", created)
+	}
+
+	// 修改其他字段, 密码保持不变
+	var found User
+	db.First(&found, userModel.ID)
+	found.Name = "winter"
+	db.Save(&found)
+	if got := storedPassword(t, db, userModel.ID); got != created {
+		t.Errorf("password rehashed on save without change: %q, want %q", got, created)
+	}
+
+	// 明文密码恰好是合法的 bcrypt 格式, 仍然要加密
+	looksHashed := hash.BcryptHash("other")
+	found.Password = looksHashed
+	db.Save(&found)
+	if got := storedPassword(t, db, userModel.ID); !hash.BcryptCheck(looksHashed, got) {
+		t.Errorf("new password %q saved as %q, want it hashed", looksHashed, got)
+	}
+
+	// 同一个对象连续保存两次, 不会重复加密
+	found.Password = "newsecret"
+	db.Save(&found)
+	db.Save(&found)
+	if got := storedPassword(t, db, userModel.ID); !hash.BcryptCheck("newsecret", got) {
+		t.Errorf("password after repeated save = %q, want hash of newsecret", got)
+	}
+}
+
+func TestBeforeSaveHashesUpdates(t *testing.T) {
+	db := openTestDB(t)
+
+	userModel := User{Name: "summer", Password: "secret"}
+	db.Create(&userModel)
+
+	tests := []struct {
+		name     string
+		update   func(db *gorm.DB) error
+		password string
+	}{
+		{"update column", func(db *gorm.DB) error {
+			return db.Model(&userModel).Update("password", "column").Error
+		}, "column"},
+		{"updates map", func(db *gorm.DB) error {
+			return db.Model(&userModel).Updates(map[string]interface{}{"Password": "map"}).Error
+		}, "map"},
+		{"updates struct", func(db *gorm.DB) error {
+			return db.Model(&userModel).Updates(User{Password: "struct"}).Error
+		}, "struct"},
+	}
+	for _, tt := range tests {
+		if err := tt.update(db); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if got := storedPassword(t, db, userModel.ID); !hash.BcryptCheck(tt.password, got) {
+			t.Errorf("%s: password saved as %q, want hash of %q", tt.name, got, tt.password)
+		}
+	}
+
+	// 只更新其他字段, 密码保持不变
+	before := storedPassword(t, db, userModel.ID)
+	db.Model(&userModel).Update("name", "winter")
+	if got := storedPassword(t, db, userModel.ID); got != before {
+		t.Errorf("password changed by unrelated update: %q, want %q", got, before)
+	}
+}
+
+func TestSetHashedPassword(t *testing.T) {
+	db := openTestDB(t)
+
+	hashed := hash.BcryptHash("secret")
+	users := []User{{Name: "a"}, {Name: "b"}}
+	for i := range users {
+		users[i].SetHashedPassword(hashed)
+	}
+	db.Create(&users)
+
+	for _, u := range users {
+		if got := storedPassword(t, db, u.ID); got != hashed {
+			t.Errorf("user %s password = %q, want %q", u.Name, got, hashed)
+		}
+	}
+}
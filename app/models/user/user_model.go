@@ -1,7 +1,12 @@
 // 存放用户 model 定义、以及对象操作的逻辑代码
 package user
 
-import "gohub/app/models"
+import (
+	"gohub/app/models"
+	"gohub/pkg/database"
+	"gohub/pkg/hash"
+	"time"
+)
 
 // 用户模型
 // 不希望讲敏感信息输出给用户,所以 Email、Phone、Password 后面设置了 json:"-"
@@ -15,5 +20,43 @@ type User struct {
 	Phone    string `json:"-"`
 	Password string `json:"-"`
 
+	// 最后一次修改密码的时间, 早于此时间签发的 JWT 令牌均视为失效
+	PasswordChangedAt *time.Time `gorm:"column:password_changed_at;" json:"-"`
+
+	// 查询时的密码, 用于判断保存前密码是否被修改, 见 BeforeSave
+	originalPassword string `gorm:"-"`
+
 	models.CommonTimestampsField
 }
+
+// Create 创建用户, 通过 User.ID 来判断是否创建成功
+func (userModel *User) Create() {
+	database.DB.Create(&userModel)
+}
+
+// Save 保存用户, 返回受影响的行数
+func (userModel *User) Save() (rowsAffected int64) {
+	result := database.DB.Save(&userModel)
+	return result.RowsAffected
+}
+
+// IsTokenRevoked 判断签发时间为 issuedAt 的令牌是否因修改密码而失效
+// 令牌的签发时间精确到秒, 与修改密码在同一秒内签发的令牌也视为失效, 宁可让用户重新登录,
+// 也不能让修改密码前一刻签发的令牌继续有效
+func (userModel *User) IsTokenRevoked(issuedAt int64) bool {
+	if userModel.PasswordChangedAt == nil {
+		return false
+	}
+	return issuedAt <= userModel.PasswordChangedAt.Unix()
+}
+
+// SetHashedPassword 设置已经加密的密码, 保存时不会再次加密, 用于 factory 批量生成数据
+func (userModel *User) SetHashedPassword(hashed string) {
+	userModel.Password = hashed
+	userModel.originalPassword = hashed
+}
+
+// ComparePassword 密码是否正确
+func (userModel *User) ComparePassword(_password string) bool {
+	return hash.BcryptCheck(_password, userModel.Password)
+}
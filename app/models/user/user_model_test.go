@@ -0,0 +1,28 @@
+package user
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsTokenRevoked(t *testing.T) {
+	changedAt := time.Date(2022, 6, 20, 16, 47, 23, 500000000, time.UTC)
+
+	tests := []struct {
+		name      string
+		changedAt *time.Time
+		issuedAt  time.Time
+		want      bool
+	}{
+		{"password never changed", nil, changedAt, false},
+		{"issued before change", &changedAt, changedAt.Add(-time.Second), true},
+		{"issued in the same second", &changedAt, changedAt.Truncate(time.Second), true},
+		{"issued after change", &changedAt, changedAt.Add(time.Second), false},
+	}
+	for _, tt := range tests {
+		userModel := User{PasswordChangedAt: tt.changedAt}
+		if got := userModel.IsTokenRevoked(tt.issuedAt.Unix()); got != tt.want {
+			t.Errorf("%s: IsTokenRevoked() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -2,7 +2,13 @@
 // 可以直接使用 user. 调用的都存在此文件中
 package user
 
-import "gohub/pkg/database"
+import (
+	"gohub/pkg/app"
+	"gohub/pkg/database"
+	"gohub/pkg/paginator"
+
+	"github.com/gin-gonic/gin"
+)
 
 // 判断 Email 是否被注册
 func IsEmailExist(email string) bool {
@@ -17,3 +23,55 @@ func IsPhoneExist(phone string) bool {
 	database.DB.Model(User{}).Where("phone = ?", phone).Count(&count)
 	return count > 0
 }
+
+// GetByPhone 通过手机号来获取用户
+func GetByPhone(phone string) (userModel User) {
+	database.DB.Where("phone = ?", phone).First(&userModel)
+	return
+}
+
+// GetByMulti 通过 手机号/Email/用户名 来获取用户
+func GetByMulti(loginID string) (userModel User) {
+	database.DB.
+		Where("phone = ?", loginID).
+		Or("email = ?", loginID).
+		Or("name = ?", loginID).
+		First(&userModel)
+	return
+}
+
+// Get 通过 ID 获取用户
+func Get(idstr string) (userModel User) {
+	database.DB.Where("id = ?", idstr).First(&userModel)
+	return
+}
+
+// GetByEmail 通过 Email 来获取用户
+func GetByEmail(email string) (userModel User) {
+	database.DB.Where("email = ?", email).First(&userModel)
+	return
+}
+
+// Paginate 分页内容
+func Paginate(c *gin.Context, perPage int) (users []User, paging paginator.Paging) {
+	paging = paginator.Paginate(
+		c,
+		database.DB.Model(User{}),
+		&users,
+		app.V1URL(database.TableName(&User{})),
+		perPage,
+	)
+	return
+}
+
+// CursorPaginate 游标分页内容, 适用于无限滚动加载
+func CursorPaginate(c *gin.Context, perPage int) (users []User, paging paginator.CursorPaging, err error) {
+	paging, err = paginator.CursorPaginate(
+		c,
+		database.DB.Model(User{}),
+		&users,
+		app.V1URL(database.TableName(&User{})),
+		perPage,
+	)
+	return
+}
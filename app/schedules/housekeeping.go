@@ -0,0 +1,69 @@
+package schedules
+
+import (
+	"gohub/pkg/app"
+	"gohub/pkg/config"
+	"gohub/pkg/logger"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// CleanDailyLogs 清理超过 log.max_age 天的日志文件
+// log.type 为 daily 时每天一个日志文件, 而 lumberjack 的 MaxAge 只清理单个文件滚动出的备份
+// log.max_age 为 0 时永久保留, 今天的日志文件无论如何都不会删除
+func CleanDailyLogs() error {
+	maxAge := config.GetInt("log.max_age")
+	filename := config.GetString("log.filename")
+	if config.GetString("log.type") != "daily" || maxAge <= 0 || len(filename) == 0 {
+		return nil
+	}
+
+	// 日志文件名为日期, 如 storage/logs/2022-06-20.log, 与 logger 一样使用服务器本地时间
+	dir := filepath.Dir(filename)
+	expiredAt := app.TimenowInTimezone().AddDate(0, 0, -maxAge)
+	today := filepath.Join(dir, time.Now().Format("2006-01-02.log"))
+
+	return removeFilesBefore(dir, "????-??-??.log", expiredAt, today)
+}
+
+// PruneMailFiles 清理 file 邮件驱动保存的, 超过 mail.file.keep_days 天的邮件
+// mail.file.keep_days 为 0 时永久保留
+func PruneMailFiles() error {
+	keepDays := config.GetInt("mail.file.keep_days")
+	dir := config.GetString("mail.file.path")
+	if keepDays <= 0 || len(dir) == 0 {
+		return nil
+	}
+
+	expiredAt := app.TimenowInTimezone().AddDate(0, 0, -keepDays)
+	return removeFilesBefore(dir, "*.eml", expiredAt)
+}
+
+// removeFilesBefore 删除 dir 目录下文件名匹配 pattern 且修改时间早于 before 的文件, keep 中的文件不删除
+func removeFilesBefore(dir string, pattern string, before time.Time, keep ...string) error {
+	files, err := filepath.Glob(filepath.Join(dir, pattern))
+	if err != nil {
+		return err
+	}
+
+	kept := make(map[string]bool, len(keep))
+	for _, file := range keep {
+		kept[filepath.Clean(file)] = true
+	}
+
+	for _, file := range files {
+		if kept[filepath.Clean(file)] {
+			continue
+		}
+		info, err := os.Stat(file)
+		if err != nil || !info.ModTime().Before(before) {
+			continue
+		}
+		if err := os.Remove(file); err != nil {
+			return err
+		}
+		logger.InfoString("定时任务", "删除过期文件", file)
+	}
+	return nil
+}
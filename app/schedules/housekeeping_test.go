@@ -0,0 +1,115 @@
+package schedules
+
+import (
+	"gohub/pkg/config"
+	"gohub/pkg/logger"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	logger.Logger = zap.NewNop()
+	os.Exit(m.Run())
+}
+
+// createFile 创建文件, 并将修改时间设置为 age 之前
+func createFile(t *testing.T, path string, age time.Duration) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	modTime := time.Now().Add(-age)
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func TestCleanDailyLogs(t *testing.T) {
+	day := 24 * time.Hour
+
+	tests := []struct {
+		name        string
+		logType     string
+		maxAge      int
+		wantRemoved bool
+	}{
+		{"expired", "daily", 7, true},
+		{"max_age 0 keeps forever", "daily", 0, false},
+		{"single log type", "single", 7, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			config.Set("log.type", tt.logType)
+			config.Set("log.max_age", tt.maxAge)
+			config.Set("log.filename", filepath.Join(dir, "logs.log"))
+
+			old := filepath.Join(dir, "2000-01-01.log")
+			today := filepath.Join(dir, time.Now().Format("2006-01-02.log"))
+			createFile(t, old, 30*day)
+			// 今天的日志文件即使修改时间很早也不删除
+			createFile(t, today, 30*day)
+
+			if err := CleanDailyLogs(); err != nil {
+				t.Fatalf("CleanDailyLogs() error = %v", err)
+			}
+			if removed := !exists(old); removed != tt.wantRemoved {
+				t.Errorf("old log removed = %v, want %v", removed, tt.wantRemoved)
+			}
+			if !exists(today) {
+				t.Error("today's log file was removed")
+			}
+		})
+	}
+}
+
+func TestPruneMailFiles(t *testing.T) {
+	day := 24 * time.Hour
+
+	tests := []struct {
+		name        string
+		keepDays    int
+		emptyPath   bool
+		wantRemoved bool
+	}{
+		{"expired", 7, false, true},
+		{"keep_days 0 keeps forever", 0, false, false},
+		{"empty path", 7, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			config.Set("mail.file.keep_days", tt.keepDays)
+			config.Set("mail.file.path", dir)
+			if tt.emptyPath {
+				config.Set("mail.file.path", "")
+			}
+
+			old := filepath.Join(dir, "old.eml")
+			fresh := filepath.Join(dir, "fresh.eml")
+			createFile(t, old, 30*day)
+			createFile(t, fresh, time.Hour)
+
+			if err := PruneMailFiles(); err != nil {
+				t.Fatalf("PruneMailFiles() error = %v", err)
+			}
+			if removed := !exists(old); removed != tt.wantRemoved {
+				t.Errorf("old mail removed = %v, want %v", removed, tt.wantRemoved)
+			}
+			if !exists(fresh) {
+				t.Error("fresh mail was removed")
+			}
+		})
+	}
+}
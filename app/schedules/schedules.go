@@ -0,0 +1,14 @@
+// Package schedules 存放定时任务, 由 schedule:run 命令执行
+package schedules
+
+import "gohub/pkg/schedule"
+
+// Initialize 注册定时任务, 时间按 app.timezone 计算
+func Initialize() {
+
+	// 按日期记录日志时, 清理超过 log.max_age 天的日志文件
+	schedule.Add("clean-daily-logs", CleanDailyLogs).DailyAt("03:00")
+
+	// 清理 file 邮件驱动保存的过期邮件
+	schedule.Add("prune-mail-files", PruneMailFiles).DailyAt("03:30")
+}
@@ -3,7 +3,7 @@ package bootstrap
 import (
 	"errors"
 	"fmt"
-	"gohub/app/models/user"
+	"gohub/app/models"
 	"gohub/pkg/config"
 	"gohub/pkg/database"
 	"gohub/pkg/logger"
@@ -47,6 +47,7 @@ func SetupDB() {
 	database.SQLDB.SetMaxIdleConns(config.GetInt("database.max_idle_connections"))
 	// 设置连接超时
 	database.SQLDB.SetConnMaxLifetime(time.Duration(config.GetInt("database.max_life_seconds")) * time.Second)
-	// 自动迁移
-	database.DB.AutoMigrate(&user.User{})
+
+	// 事务提交后清除模型的缓存标签
+	models.RegisterCacheCallbacks(database.DB)
 }
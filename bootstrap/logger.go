@@ -1,6 +1,7 @@
 package bootstrap
 
 import (
+	"gohub/pkg/app"
 	"gohub/pkg/config"
 	"gohub/pkg/logger"
 )
@@ -16,4 +17,9 @@ func SetupLogger() {
 		config.GetString("log.type"),
 		config.GetString("log.level"),
 	)
+
+	// 时区配置有误时不中断启动, 使用服务器本地时区
+	if _, err := app.Timezone(); err != nil {
+		logger.WarnString("App", "app.timezone 配置有误, 使用服务器本地时区", err.Error())
+	}
 }
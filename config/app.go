@@ -18,7 +18,7 @@ func init() {
 			// 加密会话、JWT 加密
 			"key": config.Env("APP_KEY", "33446a9dcf9ea060a0a6532b166da32f304af0d"),
 			// 用以生成链接
-			"uel": config.Env("APP_URL", "http://localhost:3000"),
+			"url": config.Env("APP_URL", "http://localhost:3000"),
 			// 设置时区, JWT 里会使用,日志记录里也会使用
 			"timezone": config.Env("APP_TIMEZONE", "Asia/Shanghai"),
 		}
@@ -0,0 +1,14 @@
+package config
+
+import "gohub/pkg/config"
+
+func init() {
+	config.AddEnv("cache", func() map[string]interface{} {
+		return map[string]interface{}{
+
+			// 缓存存储, 支持 redis 和 memory
+			// memory 存放在进程内存中, 多个进程间不共享, 只适用于测试和本地开发
+			"store": config.Env("CACHE_STORE", "redis"),
+		}
+	})
+}
@@ -0,0 +1,14 @@
+package config
+
+import "gohub/pkg/config"
+
+func init() {
+	config.AddEnv("hash", func() map[string]interface{} {
+		return map[string]interface{}{
+
+			// bcrypt 的 cost 值, 取值范围 4~31, 数值越大越安全, 加密耗时也越长
+			// 建议不小于 12, 本地开发和测试时可以调小以加快速度
+			"bcrypt_cost": config.Env("HASH_BCRYPT_COST", 14),
+		}
+	})
+}
@@ -0,0 +1,13 @@
+package config
+
+import "gohub/pkg/config"
+
+func init() {
+	config.AddEnv("limiter", func() map[string]interface{} {
+		return map[string]interface{}{
+
+			// 是否开启接口限流, 自动化测试时可在 .env.testing 中设置 LIMITER_ENABLED=false 关闭
+			"enabled": config.Env("LIMITER_ENABLED", true),
+		}
+	})
+}
@@ -6,14 +6,35 @@ func init() {
 	config.AddEnv("mail", func() map[string]interface{} {
 		return map[string]interface{}{
 
+			// 使用的驱动, 支持 smtp、log、file 和 memory
+			// log、file 和 memory 都不会真正发送邮件, 适用于本地开发、预发布环境和测试
+			"driver": config.Env("MAIL_DRIVER", "smtp"),
+
 			// 默认是 Mailhog 的配置
 			"smtp": map[string]interface{}{
 				"host":     config.Env("MAIL_HOST", "localhost"),
 				"port":     config.Env("MAIL_PORT", 1025),
 				"username": config.Env("MAIL_USERNAME", ""),
 				"password": config.Env("MAIL_PASSWORD", ""),
+
+				// 加密方式, 支持 ssl、starttls 和 none
+				"encryption": config.Env("MAIL_ENCRYPTION", "none"),
 			},
 
+			// 邮件保存为 .eml 文件的目录
+			"file": map[string]interface{}{
+				"path": config.Env("MAIL_FILE_PATH", "storage/mails"),
+
+				// 邮件保留的天数, 过期的由定时任务 prune-mail-files 清理
+				"keep_days": config.Env("MAIL_FILE_KEEP_DAYS", 7),
+			},
+
+			// 自定义邮件模板目录, 其中的同名文件覆盖编译进程序的默认模板, 模板的写法见 pkg/mail/template.go
+			"template_path": config.Env("MAIL_TEMPLATE_PATH", "resources/mails"),
+
+			"log":    map[string]interface{}{},
+			"memory": map[string]interface{}{},
+
 			"from": map[string]interface{}{
 				"address": config.Env("MAIL_FROM_ADDRESS", "gohub@example.com"),
 				"name":    config.Env("MAIL_FROM_NAME", "Gohub"),
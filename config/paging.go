@@ -0,0 +1,36 @@
+package config
+
+import "gohub/pkg/config"
+
+func init() {
+	config.AddEnv("paging", func() map[string]interface{} {
+		return map[string]interface{}{
+
+			// 默认每页条数
+			"perpage": 10,
+
+			// 每页条数的上限, 防止客户端一次请求过多数据
+			"max_perpage": 100,
+
+			// URL 中用以分辨多少页的参数
+			// 此值若修改需一并修改请求验证规则
+			"url_query_page": "page",
+
+			// URL 中用以分辨排序的参数 (使用 id 或者其他)
+			// 此值若修改需一并修改请求验证规则
+			"url_query_sort": "sort",
+
+			// URL 中用以分辨排序规则的参数 (辨别是正序还是倒序)
+			// 此值若修改需一并修改请求验证规则
+			"url_query_order": "order",
+
+			// URL 中用以分辨每页条数的参数
+			// 此值若修改需一并修改请求验证规则
+			"url_query_per_page": "per_page",
+
+			// URL 中用以传递游标分页游标的参数, 携带此参数时 (值可为空) 使用游标分页
+			// 此值若修改需一并修改请求验证规则
+			"url_query_cursor": "cursor",
+		}
+	})
+}
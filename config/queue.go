@@ -0,0 +1,31 @@
+package config
+
+import "gohub/pkg/config"
+
+func init() {
+	config.AddEnv("queue", func() map[string]interface{} {
+		return map[string]interface{}{
+
+			// 队列连接, 支持 redis 和 sync
+			// redis: 任务推送到 Redis, 由 queue:work 命令启动的 Worker 异步执行, 发送验证码等请求无需等待服务商响应
+			// sync: 不使用队列, 推送任务时直接执行, 方便本地开发, 无需启动 Worker
+			"connection": config.Env("QUEUE_CONNECTION", "redis"),
+
+			// 默认队列名称
+			"default": config.Env("QUEUE_DEFAULT", "default"),
+
+			// 任务最多尝试执行的次数, 超过后放入失败列表
+			"max_tries": config.Env("QUEUE_MAX_TRIES", 3),
+
+			// 第一次重试前的等待时间, 单位是秒, 之后每次重试翻倍
+			"backoff": config.Env("QUEUE_BACKOFF", 10),
+
+			// 任务开始执行后, 超过 retry_after 秒仍未结束, 视为 Worker 异常退出, 任务会被放回队列再次执行
+			// 需大于执行时间最长的任务所需的时间, 否则任务可能被重复执行
+			"retry_after": config.Env("QUEUE_RETRY_AFTER", 90),
+
+			// 队列中没有任务时, Worker 等待多少秒后再次检查
+			"sleep": config.Env("QUEUE_SLEEP", 1),
+		}
+	})
+}
@@ -11,6 +11,9 @@ func init() {
 
 			// 业务类存储使用 1(图片验证码、短信验证码、会话)
 			"database": config.Env("REDIS_MAIN_DB", 1),
+
+			// 缓存 cache 使用 0, 缓存清空理应当不影响业务
+			"database_cache": config.Env("REDIS_CACHE_DB", 0),
 		}
 	})
 }
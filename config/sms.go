@@ -6,6 +6,21 @@ import "gohub/pkg/config"
 func init() {
 	config.AddEnv("sms", func() map[string]interface{} {
 		return map[string]interface{}{
+
+			// 使用的驱动, 支持 aliyun、tencent、webhook 和 log
+			// log 驱动只写入日志, 不会真正发送, 适用于本地开发
+			"driver": config.Env("SMS_DRIVER", "aliyun"),
+
+			// 备用驱动, 多个以逗号分隔, 如 "tencent,webhook"
+			// 首选驱动发送失败时, 按顺序切换到备用驱动, 避免单个服务商故障导致无法发送
+			"failover": config.Env("SMS_FAILOVER", ""),
+
+			// 网络超时、服务商系统繁忙等临时性错误, 在同一驱动上的重试次数
+			"retry_times": config.Env("SMS_RETRY_TIMES", 2),
+
+			// 第一次重试前的等待时间, 单位是毫秒, 之后每次重试翻倍
+			"retry_backoff": config.Env("SMS_RETRY_BACKOFF", 200),
+
 			// 默认是阿里云的测试 sign_name 和 template_code
 			"aliyun": map[string]interface{}{
 				"access_key_id":     config.Env("SMS_ALIYUN_ACCESS_ID"),
@@ -13,6 +28,28 @@ func init() {
 				"sign_name":         config.Env("SMS_ALIYUN_SIGN_NAME", "阿里云短信测试"),
 				"template_code":     config.Env("SMS_ALIYUN_TEMPLATE_CODE", "SMS_TEMPLATE"),
 			},
+
+			// 腾讯云短信, template_code 为模板 ID
+			"tencent": map[string]interface{}{
+				"secret_id":     config.Env("SMS_TENCENT_SECRET_ID"),
+				"secret_key":    config.Env("SMS_TENCENT_SECRET_KEY"),
+				"sdk_app_id":    config.Env("SMS_TENCENT_SDK_APP_ID"),
+				"region":        config.Env("SMS_TENCENT_REGION", "ap-guangzhou"),
+				"sign_name":     config.Env("SMS_TENCENT_SIGN_NAME"),
+				"template_code": config.Env("SMS_TENCENT_TEMPLATE_ID"),
+			},
+
+			// 自建短信网关, 短信以 JSON 格式 POST 到 url, timeout 单位是秒
+			"webhook": map[string]interface{}{
+				"url":           config.Env("SMS_WEBHOOK_URL"),
+				"token":         config.Env("SMS_WEBHOOK_TOKEN"),
+				"timeout":       config.Env("SMS_WEBHOOK_TIMEOUT", 5),
+				"template_code": config.Env("SMS_WEBHOOK_TEMPLATE_CODE", "verifycode"),
+			},
+
+			"log": map[string]interface{}{
+				"template_code": "verifycode",
+			},
 		}
 	})
 }
@@ -20,6 +20,18 @@ func init() {
 			// 方便本地和 API 自动测试
 			"debug_phone_prefix": "000",
 			"debug_email_suffix": "@testing.com",
+
+			// 同一个手机号或邮箱, 两次发送之间的间隔, 单位是秒
+			"resend_interval": config.Env("VERIFY_CODE_RESEND_INTERVAL", 60),
+
+			// 同一个手机号或邮箱, 每天最多发送次数
+			"daily_limit_per_key": config.Env("VERIFY_CODE_DAILY_LIMIT_PER_KEY", 10),
+
+			// 同一个 IP, 每天最多发送次数
+			"daily_limit_per_ip": config.Env("VERIFY_CODE_DAILY_LIMIT_PER_IP", 50),
+
+			// 验证码最多允许输错的次数, 达到后验证码作废, 需重新获取
+			"max_failed_attempts": config.Env("VERIFY_CODE_MAX_FAILED_ATTEMPTS", 5),
 		}
 	})
 }
@@ -0,0 +1,56 @@
+// Package factories 存放工厂方法, 用以生成假数据
+package factories
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// seed 固定的随机数种子, 保证每个开发者和每次 CI 运行生成的数据完全一致
+const seed = 20220620
+
+// faker 使用固定种子的随机数生成器, 生成的数据可预测
+var faker = rand.New(rand.NewSource(seed))
+
+// 常见的中国姓氏拼音
+var familyNames = []string{
+	"wang", "li", "zhang", "liu", "chen", "yang", "huang", "zhao", "wu", "zhou",
+	"xu", "sun", "ma", "zhu", "hu", "guo", "he", "gao", "lin", "luo",
+}
+
+// 常见的名字拼音
+var givenNames = []string{
+	"wei", "fang", "na", "min", "jing", "li", "qiang", "lei", "jun", "yang",
+	"yong", "yan", "jie", "tao", "ming", "chao", "xiuying", "xia", "ping", "gang",
+}
+
+// 常见的邮箱服务商
+var emailDomains = []string{
+	"qq.com", "163.com", "126.com", "sina.com", "foxmail.com", "gmail.com",
+}
+
+// 国内手机号段的前三位
+var phonePrefixes = []string{
+	"130", "131", "132", "135", "136", "137", "138", "139", "150", "151",
+	"152", "155", "157", "158", "159", "176", "177", "180", "186", "188", "199",
+}
+
+// fakeName 生成拼音用户名, 如 zhangwei, 只包含字母, 满足用户名 alpha_num 的验证规则
+func fakeName() string {
+	return pick(familyNames) + pick(givenNames)
+}
+
+// fakeEmailDomain 随机返回一个邮箱服务商域名
+func fakeEmailDomain() string {
+	return pick(emailDomains)
+}
+
+// fakePhone 生成 11 位的国内手机号
+func fakePhone() string {
+	return pick(phonePrefixes) + fmt.Sprintf("%08d", faker.Intn(100000000))
+}
+
+// pick 从字符串数组中随机取一个元素
+func pick(items []string) string {
+	return items[faker.Intn(len(items))]
+}
@@ -0,0 +1,28 @@
+package factories
+
+import (
+	"fmt"
+	"gohub/app/models/user"
+)
+
+// MakeUsers 生成 times 个用户, 密码均为 secret
+func MakeUsers(times int) []user.User {
+
+	var objs []user.User
+
+	for i := 0; i < times; i++ {
+		// 加上序号后缀, 保证用户名和邮箱唯一
+		name := fmt.Sprintf("%v%d", fakeName(), i+1)
+
+		model := user.User{
+			Name:  name,
+			Email: name + "@" + fakeEmailDomain(),
+			Phone: fakePhone(),
+		}
+		// 直接使用 secret 的哈希值, 避免每个用户都做一次耗时的 bcrypt 加密
+		model.SetHashedPassword("$2a$14$ZijnWSzzNnl2gX04Gj4pI.CgygeEkV8yxP1Fb1IgOw65cjMGpbXtm")
+		objs = append(objs, model)
+	}
+
+	return objs
+}
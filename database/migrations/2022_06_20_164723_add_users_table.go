@@ -0,0 +1,36 @@
+package migrations
+
+import (
+	"database/sql"
+	"gohub/app/models"
+	"gohub/pkg/migrate"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+
+	type User struct {
+		models.BaseModel
+
+		Name     string `gorm:"type:varchar(255);not null;index"`
+		Email    string `gorm:"type:varchar(255);index;default:null"`
+		Phone    string `gorm:"type:varchar(20);index;default:null"`
+		Password string `gorm:"type:varchar(255)"`
+
+		PasswordChangedAt *time.Time `gorm:"column:password_changed_at;"`
+
+		models.CommonTimestampsField
+	}
+
+	up := func(migrator gorm.Migrator, DB *sql.DB) error {
+		return migrator.AutoMigrate(&User{})
+	}
+
+	down := func(migrator gorm.Migrator, DB *sql.DB) error {
+		return migrator.DropTable(&User{})
+	}
+
+	migrate.Add("2022_06_20_164723_add_users_table", up, down)
+}
@@ -0,0 +1,7 @@
+// Package migrations 存放数据库迁移文件
+package migrations
+
+// Initialize 触发加载本目录下所有的迁移文件 (init 方法)
+func Initialize() {
+
+}
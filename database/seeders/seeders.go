@@ -0,0 +1,13 @@
+// Package seeders 存放数据填充文件
+package seeders
+
+import "gohub/pkg/seed"
+
+// Initialize 触发加载本目录下所有的 seeder 文件 (init 方法), 并设置执行顺序
+func Initialize() {
+
+	// 指定优先于同目录下的其他文件运行
+	seed.SetRunOrder([]string{
+		"SeedUsersTable",
+	})
+}
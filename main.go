@@ -1,12 +1,15 @@
 package main
 
 import (
-	"flag"
 	"fmt"
+	"gohub/app/cmd"
+	"gohub/app/cmd/make"
 	"gohub/bootstrap"
 	"gohub/pkg/config"
+	"gohub/pkg/console"
+	"os"
 
-	"github.com/gin-gonic/gin"
+	"github.com/spf13/cobra"
 
 	btsConfig "gohub/config"
 )
@@ -18,36 +21,61 @@ func init() {
 
 func main() {
 
-	// 初始化配置信息,依赖命令行 --env 参数
-	var env string
-	flag.StringVar(&env, "env", "", "加载 .env 文件, 如 --env=testing 记载的是 .env.testing 文件")
-	flag.Parse()
-	config.InitConfig(env)
+	// 应用的主入口, 默认调用 cmd.CmdServe 命令
+	var rootCmd = &cobra.Command{
+		Use:   "gohub",
+		Short: "A simple forum project",
+		Long:  `Default will run "serve" command, you can use "-h" flag to see all subcommands`,
 
-	// 初始化 Logger
-	bootstrap.SetupLogger()
+		// rootCmd 的所有子命令都会执行以下代码
+		PersistentPreRun: func(command *cobra.Command, args []string) {
 
-	// 设置 gin 的运行模式,支持 debug, release, test
-	// release 会屏蔽调试信息, 官方建议生产环境中使用
-	// 非 release 模式 gin 终端打印太多信息,干扰到我们程序中的 log
-	// 故此设置为 release, 有特殊情况手动改为 debug 即可
-	gin.SetMode(gin.ReleaseMode)
+			// 初始化配置信息, 依赖命令行 --env 参数
+			config.InitConfig(cmd.Env)
 
-	// 初始化 DB
-	bootstrap.SetupDB()
+			// 初始化 Logger
+			bootstrap.SetupLogger()
 
-	// 初始化 Redis
-	bootstrap.SetupRedis()
+			// 代码生成器等命令不依赖以下服务, 跳过初始化
+			if !cmd.NeedsBootstrap(command) {
+				return
+			}
 
-	// 初始化 Gin 实例
-	router := gin.New()
+			// 初始化 DB
+			bootstrap.SetupDB()
 
-	// 路由初始化
-	bootstrap.SetupRoutes(router)
+			// 初始化 Redis
+			bootstrap.SetupRedis()
 
-	// 运行服务
-	err := router.Run(":" + config.Get("app.port"))
-	if err != nil {
-		fmt.Println(err.Error())
+			// 初始化缓存
+			bootstrap.SetupCache()
+		},
+	}
+
+	// 注册子命令
+	rootCmd.AddCommand(
+		cmd.CmdServe,
+		cmd.CmdKey,
+		cmd.CmdPlay,
+		cmd.CmdMigrate,
+		cmd.CmdDBSeed,
+		cmd.CmdQueueWork,
+		cmd.CmdQueueFailed,
+		cmd.CmdQueueRetry,
+		cmd.CmdScheduleRun,
+		cmd.CmdScheduleList,
+		cmd.CmdCache,
+		make.CmdMake,
+	)
+
+	// 配置默认运行 Web 服务
+	cmd.RegisterDefaultCmd(rootCmd, cmd.CmdServe)
+
+	// 注册全局参数, --env
+	cmd.RegisterGlobalFlags(rootCmd)
+
+	// 执行主命令
+	if err := rootCmd.Execute(); err != nil {
+		console.Exit(fmt.Sprintf("Failed to run app with %v: %s", os.Args, err.Error()))
 	}
 }
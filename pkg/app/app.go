@@ -1,7 +1,10 @@
 // 应用信息
 package app
 
-import "gohub/pkg/config"
+import (
+	"gohub/pkg/config"
+	"time"
+)
 
 func IsLocal() bool {
 	return config.Get("app.env") == "local"
@@ -14,3 +17,29 @@ func IsProduction() bool {
 func IsTesting() bool {
 	return config.Get("app.env") == "testing"
 }
+
+// TimenowInTimezone 获取当前时间, 支持时区
+func TimenowInTimezone() time.Time {
+	location, _ := Timezone()
+	return time.Now().In(location)
+}
+
+// Timezone 获取 app.timezone 配置的时区, 配置有误时返回服务器本地时区以及错误信息
+// 启动时 bootstrap.SetupLogger 会检查配置并记录警告
+func Timezone() (*time.Location, error) {
+	location, err := time.LoadLocation(config.GetString("app.timezone"))
+	if err != nil {
+		return time.Local, err
+	}
+	return location, nil
+}
+
+// URL 传参 path 拼接站点的 URL
+func URL(path string) string {
+	return config.Get("app.url") + path
+}
+
+// V1URL 拼接带 v1 标示 URL
+func V1URL(path string) string {
+	return URL("/v1/" + path)
+}
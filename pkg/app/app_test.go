@@ -0,0 +1,23 @@
+package app
+
+import (
+	"gohub/pkg/config"
+	"testing"
+	"time"
+)
+
+func TestTimezone(t *testing.T) {
+	config.Set("app.timezone", "Asia/Shanghai")
+	if location, err := Timezone(); err != nil || location.String() != "Asia/Shanghai" {
+		t.Errorf("Timezone() = %v, %v, want Asia/Shanghai", location, err)
+	}
+
+	// 配置有误时使用服务器本地时区, 不会 panic
+	config.Set("app.timezone", "Mars/Olympus")
+	if location, err := Timezone(); err == nil || location != time.Local {
+		t.Errorf("Timezone() = %v, %v, want time.Local and an error", location, err)
+	}
+	if now := TimenowInTimezone(); now.Location() != time.Local {
+		t.Errorf("TimenowInTimezone() location = %v, want time.Local", now.Location())
+	}
+}
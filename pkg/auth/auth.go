@@ -0,0 +1,65 @@
+// Package auth 授权相关逻辑
+package auth
+
+import (
+	"errors"
+	"gohub/app/models/user"
+	"gohub/pkg/jwt"
+	"gohub/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Attempt 尝试登录
+func Attempt(loginID string, password string) (user.User, error) {
+	userModel := user.GetByMulti(loginID)
+	if userModel.ID == 0 {
+		return user.User{}, errors.New("账号不存在")
+	}
+
+	if !userModel.ComparePassword(password) {
+		return user.User{}, errors.New("密码错误")
+	}
+
+	return userModel, nil
+}
+
+// LoginByPhone 登录指定用户
+func LoginByPhone(phone string) (user.User, error) {
+	userModel := user.GetByPhone(phone)
+	if userModel.ID == 0 {
+		return user.User{}, errors.New("手机号未注册")
+	}
+
+	return userModel, nil
+}
+
+// GetUserByClaims 通过 JWT 载荷获取用户
+// 用户不存在, 或者令牌签发于最后一次修改密码之前时返回错误
+func GetUserByClaims(claims *jwt.JWTCustomClaims) (user.User, error) {
+	userModel := user.Get(claims.UserID)
+	if userModel.ID == 0 {
+		return user.User{}, errors.New("找不到对应用户, 用户可能已删除")
+	}
+
+	if userModel.IsTokenRevoked(claims.IssuedAt) {
+		return user.User{}, errors.New("密码已修改, 请重新登录")
+	}
+
+	return userModel, nil
+}
+
+// CurrentUser 从 gin.context 中获取当前登录用户
+func CurrentUser(c *gin.Context) user.User {
+	userModel, ok := c.MustGet("current_user").(user.User)
+	if !ok {
+		logger.LogIf(errors.New("无法获取用户"))
+		return user.User{}
+	}
+	return userModel
+}
+
+// CurrentUID 从 gin.context 中获取当前登录用户 ID
+func CurrentUID(c *gin.Context) string {
+	return c.GetString("current_user_id")
+}
@@ -0,0 +1,180 @@
+// Package cache 缓存工具类, 数据以 JSON 格式存储, 可以缓存结构体
+package cache
+
+import (
+	"encoding/json"
+	"gohub/pkg/logger"
+	"sync"
+	"time"
+
+	"github.com/spf13/cast"
+)
+
+type CacheService struct {
+	Store Store
+}
+
+var once sync.Once
+
+// Cache 全局缓存对象
+var Cache *CacheService
+
+// InitWithCacheStore 使用 store 初始化全局缓存对象
+func InitWithCacheStore(store Store) {
+	once.Do(func() {
+		Cache = &CacheService{
+			Store: store,
+		}
+	})
+}
+
+// Set 存储 obj, expireTime 为 0 时永不过期
+func Set(key string, obj interface{}, expireTime time.Duration) error {
+	return set(key, obj, expireTime, nil)
+}
+
+// set 存储 obj, 有 tags 时同时记录到标签下
+func set(key string, obj interface{}, expireTime time.Duration, tags []string) error {
+	b, err := json.Marshal(&obj)
+	if err != nil {
+		return err
+	}
+	return setRaw(key, string(b), expireTime, tags)
+}
+
+func setRaw(key string, value string, expireTime time.Duration, tags []string) error {
+	if len(tags) > 0 {
+		return Cache.Store.SetTagged(key, value, expireTime, tags...)
+	}
+	return Cache.Store.Set(key, value, expireTime)
+}
+
+// Forever 存储 obj, 永不过期
+func Forever(key string, obj interface{}) error {
+	return Set(key, obj, 0)
+}
+
+// Get 获取 key 对应的值, JSON 解析后的结果, 数字为 float64 类型
+// 读取结构体请使用 GetObject, 读取基础类型请使用 GetString、GetInt 等方法
+func Get(key string) interface{} {
+	stringValue := Cache.Store.Get(key)
+	if len(stringValue) == 0 {
+		return nil
+	}
+
+	var wanted interface{}
+	err := json.Unmarshal([]byte(stringValue), &wanted)
+	logger.LogIf(err)
+	return wanted
+}
+
+// Has 判断 key 是否存在
+func Has(key string) bool {
+	return Cache.Store.Has(key)
+}
+
+// GetObject 将 key 对应的值解析到 wanted 中, wanted 需为指针, 存在且解析成功时返回 true, 用法:
+//
+//	var users []user.User
+//	cache.GetObject("users", &users)
+func GetObject(key string, wanted interface{}) bool {
+	stringValue := Cache.Store.Get(key)
+	if len(stringValue) == 0 {
+		return false
+	}
+
+	if err := json.Unmarshal([]byte(stringValue), wanted); err != nil {
+		logger.LogIf(err)
+		return false
+	}
+	return true
+}
+
+func GetString(key string) string {
+	return cast.ToString(Get(key))
+}
+
+func GetBool(key string) bool {
+	return cast.ToBool(Get(key))
+}
+
+func GetInt(key string) int {
+	return cast.ToInt(Get(key))
+}
+
+func GetInt64(key string) int64 {
+	return cast.ToInt64(Get(key))
+}
+
+func GetUint64(key string) uint64 {
+	return cast.ToUint64(Get(key))
+}
+
+func GetFloat64(key string) float64 {
+	return cast.ToFloat64(Get(key))
+}
+
+func GetTime(key string) time.Time {
+	return cast.ToTime(Get(key))
+}
+
+func GetDuration(key string) time.Duration {
+	return cast.ToDuration(Get(key))
+}
+
+func GetStringSlice(key string) []string {
+	return cast.ToStringSlice(Get(key))
+}
+
+func GetStringMapString(key string) map[string]string {
+	return cast.ToStringMapString(Get(key))
+}
+
+// Forget 删除 key
+func Forget(key string) error {
+	return Cache.Store.Forget(key)
+}
+
+// Flush 清空所有缓存
+func Flush() error {
+	return Cache.Store.Flush()
+}
+
+// IsAlive 检查缓存存储是否可用
+func IsAlive() error {
+	return Cache.Store.IsAlive()
+}
+
+// Remember 缓存中存在 key 时直接读取到 wanted, 否则调用 callback 获取数据, 缓存 ttl 时间后写入 wanted
+// callback 返回错误时不写入缓存, 并返回该错误. 写入缓存失败时只记录日志, 数据仍会写入 wanted. 用法:
+//
+//	var categories []category.Category
+//	err := cache.Remember("categories", time.Hour, &categories, func() (interface{}, error) {
+//		return category.All()
+//	})
+func Remember(key string, ttl time.Duration, wanted interface{}, callback func() (interface{}, error)) error {
+	return remember(key, ttl, wanted, callback, nil)
+}
+
+// remember Remember 的实现, 有 tags 时写入的缓存同时记录到标签下
+func remember(key string, ttl time.Duration, wanted interface{}, callback func() (interface{}, error), tags []string) error {
+	if GetObject(key, wanted) {
+		return nil
+	}
+
+	value, err := callback()
+	if err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if err := setRaw(key, string(b), ttl, tags); err != nil {
+		logger.ErrorString("缓存", "写入 "+key+" 失败", err.Error())
+	}
+
+	// 通过 JSON 写入 wanted, 与缓存命中时读取到的数据保持一致
+	return json.Unmarshal(b, wanted)
+}
@@ -0,0 +1,182 @@
+package cache
+
+import (
+	"errors"
+	"gohub/pkg/logger"
+	"os"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	logger.Logger = zap.NewNop()
+	os.Exit(m.Run())
+}
+
+// useMemoryStore 每个测试使用新的内存存储
+func useMemoryStore() *MemoryStore {
+	store := NewMemoryStore()
+	Cache = &CacheService{Store: store}
+	return store
+}
+
+func TestMemoryStoreTTL(t *testing.T) {
+	store := useMemoryStore()
+
+	store.Set("short", "1", 20*time.Millisecond)
+	store.Set("forever", "1", 0)
+
+	if !store.Has("short") || !store.Has("forever") {
+		t.Fatal("Has() = false right after Set")
+	}
+
+	time.Sleep(30 * time.Millisecond)
+
+	if store.Has("short") {
+		t.Error(`Has("short") = true after ttl, want false`)
+	}
+	if got := store.Get("short"); got != "" {
+		t.Errorf(`Get("short") = %q after ttl, want ""`, got)
+	}
+	if !store.Has("forever") {
+		t.Error(`Has("forever") = false, want true`)
+	}
+}
+
+// TestMemoryStoreExpiredGetKeepsNewValue 读取到过期数据的 Get, 不能删除并发的 Set 写入的新数据
+func TestMemoryStoreExpiredGetKeepsNewValue(t *testing.T) {
+	store := useMemoryStore()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 2000; j++ {
+				store.Get("key")
+				runtime.Gosched()
+			}
+		}()
+	}
+
+	lost := 0
+	for i := 0; i < 2000; i++ {
+		store.Set("key", "stale", time.Nanosecond)
+		runtime.Gosched()
+		store.Set("key", "fresh", time.Hour)
+		runtime.Gosched()
+		if store.Get("key") != "fresh" {
+			lost++
+		}
+	}
+	wg.Wait()
+
+	if lost > 0 {
+		t.Errorf("fresh value deleted by a concurrent Get of the expired one %d times", lost)
+	}
+}
+
+func TestMemoryStoreTagIndex(t *testing.T) {
+	store := useMemoryStore()
+
+	store.SetTagged("a", "1", time.Hour, "users", "user:1")
+	store.SetTagged("b", "1", time.Nanosecond, "users")
+	store.SetTagged("c", "1", time.Hour, "users")
+
+	// 删除和过期的 key 移出所属的标签, 空标签一并删除
+	store.Forget("a")
+	store.Get("b")
+	if _, ok := store.tags["user:1"]; ok {
+		t.Errorf("tag user:1 = %v after its only key was forgotten, want removed", store.tags["user:1"])
+	}
+	if got := len(store.tags["users"]); got != 1 {
+		t.Errorf("tag users has %d keys, want 1", got)
+	}
+	if len(store.keyTags) != 1 {
+		t.Errorf("keyTags = %v, want only c", store.keyTags)
+	}
+
+	store.FlushTags("users")
+	if store.Has("c") || len(store.tags) != 0 || len(store.keyTags) != 0 {
+		t.Errorf("after FlushTags: items = %v, tags = %v, keyTags = %v, want all empty", store.items, store.tags, store.keyTags)
+	}
+}
+
+func TestRemember(t *testing.T) {
+	errCallback := errors.New("callback failed")
+
+	tests := []struct {
+		name      string
+		cached    interface{} // 非 nil 时预先写入缓存
+		value     interface{}
+		err       error
+		want      string
+		wantErr   error
+		wantCalls int
+	}{
+		{name: "miss", value: "fresh", want: "fresh", wantCalls: 1},
+		{name: "hit", cached: "cached", value: "fresh", want: "cached", wantCalls: 0},
+		{name: "callback error", err: errCallback, wantErr: errCallback, wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useMemoryStore()
+			if tt.cached != nil {
+				Set("key", tt.cached, time.Minute)
+			}
+
+			calls := 0
+			var got string
+			err := Remember("key", time.Minute, &got, func() (interface{}, error) {
+				calls++
+				return tt.value, tt.err
+			})
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Remember() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Remember() wanted = %q, want %q", got, tt.want)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("callback called %d times, want %d", calls, tt.wantCalls)
+			}
+
+			// 回调出错时不写入缓存, 否则写入回调的值或保留原值
+			if tt.err != nil && Has("key") {
+				t.Error("Remember() cached the value although callback failed")
+			}
+			if tt.err == nil && GetString("key") != tt.want {
+				t.Errorf(`GetString("key") = %q, want %q`, GetString("key"), tt.want)
+			}
+		})
+	}
+}
+
+func TestRememberStruct(t *testing.T) {
+	useMemoryStore()
+
+	type user struct {
+		ID   uint64
+		Name string
+	}
+
+	var first, second user
+	callback := func() (interface{}, error) {
+		return user{ID: 1, Name: "summer"}, nil
+	}
+	if err := Remember("user:1", time.Minute, &first, callback); err != nil {
+		t.Fatal(err)
+	}
+	if err := Remember("user:1", time.Minute, &second, callback); err != nil {
+		t.Fatal(err)
+	}
+	if first != second || second.Name != "summer" {
+		t.Errorf("Remember() = %+v and %+v, want the same user", first, second)
+	}
+}
@@ -0,0 +1,26 @@
+package cache
+
+import "time"
+
+// Store 缓存存储驱动, value 为 JSON 序列化后的字符串
+type Store interface {
+	// Set 存储 key 对应的 value, expireTime 为 0 时永不过期
+	Set(key string, value string, expireTime time.Duration) error
+	// Get 获取 key 对应的 value, 不存在时返回空字符串
+	Get(key string) string
+	// Has 判断 key 是否存在
+	Has(key string) bool
+	// Forget 删除 key
+	Forget(key string) error
+	// Flush 清空所有缓存
+	Flush() error
+
+	// SetTagged 同 Set, 并将 key 记录到标签下, 用以按标签批量清除
+	// 存储和记录标签需在同一操作中完成, 避免两者之间执行的 FlushTags 漏掉 key
+	SetTagged(key string, value string, expireTime time.Duration, tags ...string) error
+	// FlushTags 清除标签下的所有 key
+	FlushTags(tags ...string) error
+
+	// IsAlive 检查存储是否可用
+	IsAlive() error
+}
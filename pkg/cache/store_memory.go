@@ -0,0 +1,140 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+// MemoryStore 实现 cache.Store interface, 数据存放在进程内存中
+// 不能在多个进程间共享, 适用于测试和单机的本地开发
+type MemoryStore struct {
+	mu    sync.RWMutex
+	items map[string]memoryItem
+	tags  map[string]map[string]struct{}
+
+	// keyTags 每个 key 所属的标签, 删除 key 时据此将其移出标签, 无需遍历标签下所有的 key
+	keyTags map[string]map[string]struct{}
+}
+
+type memoryItem struct {
+	value    string
+	expireAt time.Time // 零值表示永不过期
+}
+
+// NewMemoryStore 创建内存缓存存储
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		items:   make(map[string]memoryItem),
+		tags:    make(map[string]map[string]struct{}),
+		keyTags: make(map[string]map[string]struct{}),
+	}
+}
+
+func (s *MemoryStore) Set(key string, value string, expireTime time.Duration) error {
+	item := memoryItem{value: value}
+	if expireTime > 0 {
+		item.expireAt = time.Now().Add(expireTime)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.items[key] = item
+	return nil
+}
+
+func (s *MemoryStore) Get(key string) string {
+	s.mu.RLock()
+	item, ok := s.items[key]
+	s.mu.RUnlock()
+
+	if !ok {
+		return ""
+	}
+	if !item.expired() {
+		return item.value
+	}
+
+	// 已过期的数据在读取时删除, 释放读锁后可能已被并发的 Set 覆盖, 持有写锁后需再次检查
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if item, ok := s.items[key]; ok && item.expired() {
+		s.remove(key)
+	}
+	return ""
+}
+
+func (s *MemoryStore) Has(key string) bool {
+	return len(s.Get(key)) > 0
+}
+
+func (s *MemoryStore) Forget(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.remove(key)
+	return nil
+}
+
+func (s *MemoryStore) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.items = make(map[string]memoryItem)
+	s.tags = make(map[string]map[string]struct{})
+	s.keyTags = make(map[string]map[string]struct{})
+	return nil
+}
+
+func (s *MemoryStore) SetTagged(key string, value string, expireTime time.Duration, tags ...string) error {
+	item := memoryItem{value: value}
+	if expireTime > 0 {
+		item.expireAt = time.Now().Add(expireTime)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.items[key] = item
+	for _, tag := range tags {
+		if _, ok := s.tags[tag]; !ok {
+			s.tags[tag] = make(map[string]struct{})
+		}
+		s.tags[tag][key] = struct{}{}
+
+		if _, ok := s.keyTags[key]; !ok {
+			s.keyTags[key] = make(map[string]struct{})
+		}
+		s.keyTags[key][tag] = struct{}{}
+	}
+	return nil
+}
+
+func (s *MemoryStore) FlushTags(tags ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, tag := range tags {
+		for key := range s.tags[tag] {
+			s.remove(key)
+		}
+		delete(s.tags, tag)
+	}
+	return nil
+}
+
+// remove 删除 key, 并将其移出所属的标签, 标签为空时一并删除, 调用方需持有锁
+func (s *MemoryStore) remove(key string) {
+	delete(s.items, key)
+	for tag := range s.keyTags[key] {
+		delete(s.tags[tag], key)
+		if len(s.tags[tag]) == 0 {
+			delete(s.tags, tag)
+		}
+	}
+	delete(s.keyTags, key)
+}
+
+// expired 是否已过期
+func (item memoryItem) expired() bool {
+	return !item.expireAt.IsZero() && time.Now().After(item.expireAt)
+}
+
+func (s *MemoryStore) IsAlive() error {
+	return nil
+}
@@ -0,0 +1,133 @@
+package cache
+
+import (
+	"gohub/pkg/config"
+	"gohub/pkg/redis"
+	"time"
+)
+
+// setTaggedScript 写入缓存并记录到标签集合
+// KEYS[1] 为缓存的 key, 其余为标签集合, ARGV[1] 为缓存的值, ARGV[2] 为过期时间 (毫秒, 0 表示永不过期)
+// 标签集合的过期时间不短于其中最长的缓存, 集合中的 key 全部过期后, 集合也随之过期
+var setTaggedScript = redis.NewScript(`
+local ttl = tonumber(ARGV[2])
+if ttl > 0 then
+	redis.call('set', KEYS[1], ARGV[1], 'PX', ttl)
+else
+	redis.call('set', KEYS[1], ARGV[1])
+end
+for i = 2, #KEYS do
+	-- 集合已存在且 pttl 为 -1, 说明其中有永不过期的缓存, 集合也保持永不过期
+	local current = redis.call('pttl', KEYS[i])
+	redis.call('sadd', KEYS[i], KEYS[1])
+	if ttl == 0 then
+		redis.call('persist', KEYS[i])
+	elseif current == -2 or (current >= 0 and current < ttl) then
+		redis.call('pexpire', KEYS[i], ttl)
+	end
+end
+return 1
+`)
+
+// flushTagsScript 删除每个标签集合中的 key, 以及集合本身
+var flushTagsScript = redis.NewScript(`
+local count = 0
+for i = 1, #KEYS do
+	for _, key in ipairs(redis.call('smembers', KEYS[i])) do
+		count = count + redis.call('del', key)
+	end
+	redis.call('del', KEYS[i])
+end
+return count
+`)
+
+// RedisStore 实现 cache.Store interface
+type RedisStore struct {
+	RedisClient *redis.RedisClient
+	KeyPrefix   string
+}
+
+// NewRedisStore 使用独立的 Redis 连接创建缓存存储, 建议使用单独的 db, 清空缓存不影响业务数据
+func NewRedisStore(address string, username string, password string, db int) *RedisStore {
+	rs := &RedisStore{}
+	rs.RedisClient = redis.NewClient(address, username, password, db)
+	rs.KeyPrefix = config.GetString("app.name") + ":cache:"
+	return rs
+}
+
+func (s *RedisStore) Set(key string, value string, expireTime time.Duration) error {
+	return s.RedisClient.SetContext(s.RedisClient.Context, s.KeyPrefix+key, value, expireTime)
+}
+
+func (s *RedisStore) Get(key string) string {
+	return s.RedisClient.Get(s.KeyPrefix + key)
+}
+
+func (s *RedisStore) Has(key string) bool {
+	return s.RedisClient.Has(s.KeyPrefix + key)
+}
+
+func (s *RedisStore) Forget(key string) error {
+	_, err := s.RedisClient.DelContext(s.RedisClient.Context, s.KeyPrefix+key)
+	return err
+}
+
+// Flush 只删除 KeyPrefix 前缀的 key, 不影响同一个 db 中的其他数据
+func (s *RedisStore) Flush() error {
+	return s.deleteMatch(s.KeyPrefix + "*")
+}
+
+// SetTagged 标签使用 Redis 集合存储其下的 key, 写入缓存和标签在同一个脚本中完成
+func (s *RedisStore) SetTagged(key string, value string, expireTime time.Duration, tags ...string) error {
+	keys := make([]string, 0, len(tags)+1)
+	keys = append(keys, s.KeyPrefix+key)
+	for _, tag := range tags {
+		keys = append(keys, s.tagKey(tag))
+	}
+	_, err := s.RedisClient.RunContext(s.RedisClient.Context, setTaggedScript, keys, value, expireTime.Milliseconds())
+	return err
+}
+
+// FlushTags 删除标签下的所有 key 以及标签集合本身
+func (s *RedisStore) FlushTags(tags ...string) error {
+	keys := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		keys = append(keys, s.tagKey(tag))
+	}
+	_, err := s.RedisClient.RunContext(s.RedisClient.Context, flushTagsScript, keys)
+	return err
+}
+
+// tagKey 标签集合的 key
+func (s *RedisStore) tagKey(tag string) string {
+	return s.KeyPrefix + "tag:" + tag
+}
+
+// deleteMatch 使用 SCAN 分批删除匹配 pattern 的 key, 避免 KEYS 命令阻塞 Redis
+func (s *RedisStore) deleteMatch(pattern string) error {
+	ctx := s.RedisClient.Context
+	iter := s.RedisClient.Client.Scan(ctx, 0, pattern, 500).Iterator()
+
+	keys := make([]string, 0, 500)
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+		if len(keys) == 500 {
+			if _, err := s.RedisClient.DelContext(ctx, keys...); err != nil {
+				return err
+			}
+			keys = keys[:0]
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+	if len(keys) > 0 {
+		_, err := s.RedisClient.DelContext(ctx, keys...)
+		return err
+	}
+	return nil
+}
+
+func (s *RedisStore) IsAlive() error {
+	return s.RedisClient.Ping()
+}
@@ -0,0 +1,44 @@
+package cache
+
+import "time"
+
+// TaggedCache 带标签的缓存, 同一标签下的缓存可以一起清除
+type TaggedCache struct {
+	tags []string
+}
+
+// Tags 为接下来写入的缓存设置标签, 用法:
+//
+//	cache.Tags("users", "user:42").Set("user:42:profile", profile, time.Hour)
+//	cache.Tags("users").Flush()  // 清除所有带 users 标签的缓存
+func Tags(tags ...string) *TaggedCache {
+	return &TaggedCache{tags: tags}
+}
+
+// Set 存储 obj 并记录到标签下, expireTime 为 0 时永不过期
+func (tc *TaggedCache) Set(key string, obj interface{}, expireTime time.Duration) error {
+	return set(key, obj, expireTime, tc.tags)
+}
+
+// Forever 存储 obj 并记录到标签下, 永不过期
+func (tc *TaggedCache) Forever(key string, obj interface{}) error {
+	return tc.Set(key, obj, 0)
+}
+
+// Remember 同 cache.Remember, 写入的缓存会记录到标签下
+func (tc *TaggedCache) Remember(key string, ttl time.Duration, wanted interface{}, callback func() (interface{}, error)) error {
+	return remember(key, ttl, wanted, callback, tc.tags)
+}
+
+// Flush 清除标签下的所有缓存
+func (tc *TaggedCache) Flush() error {
+	return FlushTags(tc.tags...)
+}
+
+// FlushTags 清除标签下的所有缓存, 缓存未初始化时 (如部分命令行) 直接返回
+func FlushTags(tags ...string) error {
+	if Cache == nil || len(tags) == 0 {
+		return nil
+	}
+	return Cache.Store.FlushTags(tags...)
+}
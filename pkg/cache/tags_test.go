@@ -0,0 +1,109 @@
+package cache
+
+import (
+	"gohub/pkg/redis"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+// newTestRedisStore 使用 miniredis 创建 RedisStore
+func newTestRedisStore(t *testing.T) (*RedisStore, *miniredis.Miniredis) {
+	mr := miniredis.RunT(t)
+	return &RedisStore{
+		RedisClient: redis.NewClient(mr.Addr(), "", "", 0),
+		KeyPrefix:   "test:cache:",
+	}, mr
+}
+
+func TestTaggedCacheFlush(t *testing.T) {
+	redisStore, _ := newTestRedisStore(t)
+
+	stores := map[string]Store{
+		"memory": NewMemoryStore(),
+		"redis":  redisStore,
+	}
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			Cache = &CacheService{Store: store}
+
+			Tags("users", "user:1").Set("user:1:profile", "a", time.Minute)
+			Tags("users", "user:2").Forever("user:2:profile", "b")
+			Tags("users").Set("users:count", 2, time.Minute)
+			Set("plain", 1, time.Minute)
+
+			if err := FlushTags("user:1"); err != nil {
+				t.Fatalf("FlushTags() error = %v", err)
+			}
+			if Has("user:1:profile") || !Has("user:2:profile") || !Has("users:count") {
+				t.Error(`FlushTags("user:1") should only remove user:1:profile`)
+			}
+
+			if err := Tags("users").Flush(); err != nil {
+				t.Fatalf("Flush() error = %v", err)
+			}
+			if Has("user:2:profile") || Has("users:count") {
+				t.Error(`Tags("users").Flush() left tagged keys behind`)
+			}
+			if !Has("plain") {
+				t.Error("Tags().Flush() removed an untagged key")
+			}
+		})
+	}
+}
+
+func TestTaggedRemember(t *testing.T) {
+	useMemoryStore()
+
+	var got string
+	err := Tags("users").Remember("users:names", time.Minute, &got, func() (interface{}, error) {
+		return "summer", nil
+	})
+	if err != nil || got != "summer" {
+		t.Fatalf("Remember() = %q, %v", got, err)
+	}
+
+	FlushTags("users")
+	if Has("users:names") {
+		t.Error("Remember() did not record the key under its tags")
+	}
+}
+
+// TestRedisTagSetExpiry 标签集合的过期时间不短于其中最长的缓存
+func TestRedisTagSetExpiry(t *testing.T) {
+	store, mr := newTestRedisStore(t)
+	tagKey := store.tagKey("users")
+
+	store.SetTagged("a", "1", time.Hour, "users")
+	store.SetTagged("b", "1", time.Minute, "users")
+	if ttl := mr.TTL(tagKey); ttl != time.Hour {
+		t.Errorf("tag ttl = %v, want %v", ttl, time.Hour)
+	}
+
+	store.SetTagged("c", "1", 2*time.Hour, "users")
+	if ttl := mr.TTL(tagKey); ttl != 2*time.Hour {
+		t.Errorf("tag ttl = %v, want %v", ttl, 2*time.Hour)
+	}
+
+	// 永不过期的缓存, 标签集合也永不过期
+	store.SetTagged("d", "1", 0, "users")
+	store.SetTagged("e", "1", time.Minute, "users")
+	if ttl := mr.TTL(tagKey); ttl != 0 {
+		t.Errorf("tag ttl = %v, want no expiry", ttl)
+	}
+}
+
+func TestRedisFlushOnlyPrefixedKeys(t *testing.T) {
+	store, mr := newTestRedisStore(t)
+	mr.Set("other:key", "1")
+	store.Set("a", "1", 0)
+	store.SetTagged("b", "1", 0, "users")
+
+	if err := store.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if keys := mr.Keys(); len(keys) != 1 || keys[0] != "other:key" {
+		t.Errorf("keys after Flush() = %v, want [other:key]", keys)
+	}
+}
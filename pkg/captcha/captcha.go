@@ -60,5 +60,14 @@ func (c *Captcha) VerifyCaptcha(id string, answer string) (match bool) {
 
 	// 	第三个参数是验证后是否删除, 我们选择false
 	// 这样方便用户多次提交, 防止表单提交错误需要多次输入图形验证码
+	// 业务操作成功后, 需调用 ConsumeCaptcha 作废验证码, 防止重复使用
 	return c.Base64Captcha.Verify(id, answer, false)
 }
+
+// ConsumeCaptcha 作废验证码, 在验证通过且业务操作成功后调用
+func (c *Captcha) ConsumeCaptcha(id string) {
+	if !app.IsProduction() && id == config.GetString("captcha.testing_key") {
+		return
+	}
+	c.Base64Captcha.Store.Get(id, true)
+}
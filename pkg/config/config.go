@@ -131,3 +131,8 @@ func GetBool(path string, defaultValue ...interface{}) bool {
 func GetStringMapString(path string) map[string]string {
 	return viper.GetStringMapString(path)
 }
+
+// Set 设置配置项, 会覆盖 .env 和 config 目录下的配置, 主要用于测试
+func Set(path string, value interface{}) {
+	viper.Set(path, value)
+}
@@ -3,7 +3,9 @@ package database
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
+	"gohub/pkg/config"
 
 	"gorm.io/gorm"
 	gormlogger "gorm.io/gorm/logger"
@@ -33,3 +35,85 @@ func Connect(dbConfig gorm.Dialector, _logger gormlogger.Interface) {
 		fmt.Println(err.Error())
 	}
 }
+
+// CurrentDatabase 获取当前连接的数据库名称
+func CurrentDatabase() (dbname string) {
+	dbname = DB.Migrator().CurrentDatabase()
+	return
+}
+
+// DeleteAllTables 删除当前数据库的所有数据表
+func DeleteAllTables() error {
+	var err error
+	switch config.Get("database.connection") {
+	case "mysql":
+		err = deleteMySQLTables()
+	case "sqlite":
+		err = deleteAllSqliteTables()
+	default:
+		panic(errors.New("database connection not supported"))
+	}
+	return err
+}
+
+func deleteAllSqliteTables() error {
+	tables := []string{}
+
+	// 读取所有数据表
+	err := DB.Table("sqlite_master").
+		Where("type = ?", "table").
+		Pluck("name", &tables).
+		Error
+	if err != nil {
+		return err
+	}
+
+	// 删除所有表
+	for _, table := range tables {
+		// sqlite_sequence 是 sqlite 内部使用的表, 不能删除
+		if table == "sqlite_sequence" {
+			continue
+		}
+		err := DB.Migrator().DropTable(table)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func deleteMySQLTables() error {
+	dbname := CurrentDatabase()
+	tables := []string{}
+
+	// 读取所有数据表
+	err := DB.Table("information_schema.tables").
+		Where("table_schema = ?", dbname).
+		Pluck("table_name", &tables).
+		Error
+	if err != nil {
+		return err
+	}
+
+	// 暂时关闭外键检测
+	DB.Exec("SET foreign_key_checks = 0;")
+
+	// 删除所有表
+	for _, table := range tables {
+		err := DB.Migrator().DropTable(table)
+		if err != nil {
+			return err
+		}
+	}
+
+	// 开启 MySQL 外键检测
+	DB.Exec("SET foreign_key_checks = 1;")
+	return nil
+}
+
+// TableName 获取模型对应的数据表名称
+func TableName(obj interface{}) string {
+	stmt := &gorm.Statement{DB: DB}
+	stmt.Parse(obj)
+	return stmt.Schema.Table
+}
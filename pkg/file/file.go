@@ -0,0 +1,24 @@
+// Package file 文件操作辅助函数
+package file
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// Put 将数据存入文件, 目录不存在时自动创建
+func Put(data []byte, to string) error {
+	if err := os.MkdirAll(filepath.Dir(to), os.ModePerm); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(to, data, 0644)
+}
+
+// Exists 判断文件是否存在
+func Exists(fileToCheck string) bool {
+	if _, err := os.Stat(fileToCheck); os.IsNotExist(err) {
+		return false
+	}
+	return true
+}
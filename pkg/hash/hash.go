@@ -0,0 +1,31 @@
+// Package hash 哈希操作类
+package hash
+
+import (
+	"gohub/pkg/config"
+	"gohub/pkg/logger"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// BcryptHash 使用 bcrypt 对密码进行加密
+func BcryptHash(password string) string {
+	// GenerateFromPassword 的第二个参数是 cost 值, 读取配置信息 hash.bcrypt_cost
+	bytes, err := bcrypt.GenerateFromPassword([]byte(password), config.GetInt("hash.bcrypt_cost"))
+	logger.LogIf(err)
+
+	return string(bytes)
+}
+
+// BcryptCheck 对比明文密码和数据库的哈希值
+func BcryptCheck(password, hash string) bool {
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	return err == nil
+}
+
+// BcryptIsHashed 判断字符串是否是哈希过的数据
+func BcryptIsHashed(str string) bool {
+	// 能解析出 cost 值, 说明是合法的 bcrypt 哈希 (长度、前缀和格式都正确)
+	_, err := bcrypt.Cost([]byte(str))
+	return err == nil
+}
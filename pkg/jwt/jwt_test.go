@@ -0,0 +1,139 @@
+package jwt
+
+import (
+	"gohub/pkg/config"
+	"gohub/pkg/logger"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	jwtpkg "github.com/golang-jwt/jwt"
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	logger.Logger = zap.NewNop()
+	gin.SetMode(gin.TestMode)
+	config.Set("jwt.expire_time", 120)
+	os.Exit(m.Run())
+}
+
+func newTestJWT() *JWT {
+	return &JWT{SignKey: []byte("test-key"), MaxRefresh: time.Hour}
+}
+
+// newContext 创建带有 Authorization 请求头的 gin.Context
+func newContext(authorization string) *gin.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("GET", "/", nil)
+	if len(authorization) > 0 {
+		c.Request.Header.Set("Authorization", authorization)
+	}
+	return c
+}
+
+// signedToken 使用 key 签发一个 issuedAt 签发, expiresAt 过期的令牌
+func signedToken(t *testing.T, key string, issuedAt, expiresAt time.Time) string {
+	j := &JWT{SignKey: []byte(key)}
+	token, err := j.createToken(JWTCustomClaims{
+		UserID:       "1",
+		UserName:     "summer",
+		ExpireAtTime: expiresAt.Unix(),
+		StandardClaims: jwtpkg.StandardClaims{
+			IssuedAt:  issuedAt.Unix(),
+			ExpiresAt: expiresAt.Unix(),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func TestParserToken(t *testing.T) {
+	now := time.Now()
+	valid := signedToken(t, "test-key", now, now.Add(time.Hour))
+
+	// alg 为 none 的令牌不允许通过验证
+	none, err := jwtpkg.NewWithClaims(jwtpkg.SigningMethodNone, JWTCustomClaims{UserID: "1"}).
+		SignedString(jwtpkg.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		authorization string
+		wantErr       error
+	}{
+		{"valid", "Bearer " + valid, nil},
+		{"empty header", "", ErrHeaderEmpty},
+		{"not bearer", "Token " + valid, ErrHeaderMalformed},
+		{"no token", "Bearer", ErrHeaderMalformed},
+		{"malformed", "Bearer not-a-token", ErrTokenMalformed},
+		{"wrong key", "Bearer " + signedToken(t, "other-key", now, now.Add(time.Hour)), ErrTokenSignatureInvalid},
+		{"expired", "Bearer " + signedToken(t, "test-key", now.Add(-2*time.Hour), now.Add(-time.Hour)), ErrTokenExpired},
+		{"alg none", "Bearer " + none, ErrTokenInvalid},
+	}
+	for _, tt := range tests {
+		claims, err := newTestJWT().ParserToken(newContext(tt.authorization))
+		if err != tt.wantErr {
+			t.Errorf("%s: ParserToken() error = %v, want %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if err == nil && claims.UserID != "1" {
+			t.Errorf("%s: ParserToken() UserID = %q, want %q", tt.name, claims.UserID, "1")
+		}
+	}
+}
+
+func TestParserRefreshToken(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{"not expired", signedToken(t, "test-key", now, now.Add(time.Hour)), nil},
+		{"expired within max refresh", signedToken(t, "test-key", now.Add(-30*time.Minute), now.Add(-time.Minute)), nil},
+		{"issued before max refresh", signedToken(t, "test-key", now.Add(-2*time.Hour), now.Add(-time.Minute)), ErrTokenExpiredMaxRefresh},
+		{"expired with wrong key", signedToken(t, "other-key", now.Add(-30*time.Minute), now.Add(-time.Minute)), ErrTokenSignatureInvalid},
+		{"malformed", "not-a-token", ErrTokenMalformed},
+	}
+	for _, tt := range tests {
+		_, err := newTestJWT().ParserRefreshToken(newContext("Bearer " + tt.token))
+		if err != tt.wantErr {
+			t.Errorf("%s: ParserRefreshToken() error = %v, want %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestRefreshClaims(t *testing.T) {
+	j := newTestJWT()
+	issuedAt := time.Now().Add(-30 * time.Minute)
+	expired := signedToken(t, "test-key", issuedAt, time.Now().Add(-time.Minute))
+
+	claims, err := j.ParserRefreshToken(newContext("Bearer " + expired))
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := j.RefreshClaims(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 新令牌可以正常使用, 过期时间更新, 首次签名时间保持不变
+	refreshed, err := j.ParserToken(newContext("Bearer " + token))
+	if err != nil {
+		t.Fatalf("ParserToken(refreshed) error = %v", err)
+	}
+	if refreshed.ExpiresAt <= time.Now().Unix() || refreshed.ExpiresAt != refreshed.ExpireAtTime {
+		t.Errorf("refreshed ExpiresAt = %d, ExpireAtTime = %d, want the same future time", refreshed.ExpiresAt, refreshed.ExpireAtTime)
+	}
+	if refreshed.IssuedAt != issuedAt.Unix() {
+		t.Errorf("refreshed IssuedAt = %d, want %d", refreshed.IssuedAt, issuedAt.Unix())
+	}
+}
@@ -0,0 +1,92 @@
+// Package limiter 处理限流逻辑
+package limiter
+
+import (
+	"gohub/pkg/config"
+	"gohub/pkg/logger"
+	"gohub/pkg/redis"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	limiterlib "github.com/ulule/limiter/v3"
+	sredis "github.com/ulule/limiter/v3/drivers/store/redis"
+)
+
+// store 所有限流规则共用的 Redis 存储
+// 创建存储时会向 Redis 预加载 Lua 脚本, 只创建一次, 之后复用
+var redisStore limiterlib.Store
+var storeMutex sync.Mutex
+
+// GetKeyIP 获取 Limitor 的 Key, IP
+func GetKeyIP(c *gin.Context) string {
+	return c.ClientIP()
+}
+
+// GetKeyRouteWithIP Limitor 的 Key, 路由+IP, 针对单个路由做限流
+func GetKeyRouteWithIP(c *gin.Context) string {
+	return routeToKeyString(c.FullPath()) + c.ClientIP()
+}
+
+// CheckRate 检测请求是否超额
+func CheckRate(c *gin.Context, key string, formatted string) (limiterlib.Context, error) {
+
+	// 实例化依赖的 limiter 包的 limiter.Rate 对象
+	var context limiterlib.Context
+	rate, err := limiterlib.NewRateFromFormatted(formatted)
+	if err != nil {
+		logger.LogIf(err)
+		return context, err
+	}
+
+	// 获取共用的存储
+	store, err := getStore()
+	if err != nil {
+		logger.LogIf(err)
+		return context, err
+	}
+
+	// 使用上面的初始化的 limiter.Rate 对象和存储对象
+	limiterObj := limiterlib.New(store, rate)
+
+	// 获取限流的结果
+	if c.GetBool("limiter-once") {
+		// Peek() 取结果, 不增加访问次数
+		return limiterObj.Peek(c, key)
+	} else {
+
+		// 确保多个路由组里调用 LimitIP 进行限流时, 只增加一次访问次数
+		c.Set("limiter-once", true)
+
+		// Get() 取结果且增加访问次数
+		return limiterObj.Get(c, key)
+	}
+}
+
+// getStore 获取共用的存储, 第一次调用时创建, 使用我们程序里共用的 redis.Redis 对象
+// 创建失败 (如 Redis 暂时无法连接) 时不缓存, 下一个请求会重新创建
+func getStore() (limiterlib.Store, error) {
+	storeMutex.Lock()
+	defer storeMutex.Unlock()
+
+	if redisStore != nil {
+		return redisStore, nil
+	}
+
+	store, err := sredis.NewStoreWithOptions(redis.Redis.Client, limiterlib.StoreOptions{
+		// 为 limiter 设置前缀, 保持 redis 里数据的整洁
+		Prefix: config.GetString("app.name") + ":limiter",
+	})
+	if err != nil {
+		return nil, err
+	}
+	redisStore = store
+	return redisStore, nil
+}
+
+// routeToKeyString 辅助方法, 将 URL 中的 / 格式为 -
+func routeToKeyString(routeName string) string {
+	routeName = strings.ReplaceAll(routeName, "/", "-")
+	routeName = strings.ReplaceAll(routeName, ":", "_")
+	return routeName
+}
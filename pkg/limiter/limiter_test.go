@@ -0,0 +1,51 @@
+package limiter
+
+import (
+	"gohub/pkg/logger"
+	"gohub/pkg/redis"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+func TestCheckRateReusesStore(t *testing.T) {
+	logger.Logger = zap.NewNop()
+	gin.SetMode(gin.TestMode)
+	mr := miniredis.RunT(t)
+	redis.Redis = redis.NewClient(mr.Addr(), "", "", 0)
+
+	check := func() (reached bool, remaining int64) {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest("GET", "/", nil)
+		result, err := CheckRate(c, "127.0.0.1", "2-M")
+		if err != nil {
+			t.Fatalf("CheckRate() error = %v", err)
+		}
+		return result.Reached, result.Remaining
+	}
+
+	// 第一个请求创建存储, 预加载 Lua 脚本
+	commands := mr.CommandCount()
+	check()
+	first := mr.CommandCount() - commands
+	store, _ := getStore()
+
+	// 之后的请求复用存储, 只执行限流脚本
+	commands = mr.CommandCount()
+	if reached, remaining := check(); reached || remaining != 0 {
+		t.Errorf("second request reached = %v, remaining = %d, want false, 0", reached, remaining)
+	}
+	if second := mr.CommandCount() - commands; second >= first {
+		t.Errorf("Redis commands of the second request = %d, want fewer than the first (%d)", second, first)
+	}
+	if reused, _ := getStore(); reused != store {
+		t.Error("getStore() created a new store")
+	}
+
+	if reached, _ := check(); !reached {
+		t.Error("third request reached = false, want true")
+	}
+}
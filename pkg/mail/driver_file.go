@@ -0,0 +1,37 @@
+package mail
+
+import (
+	"fmt"
+	"gohub/pkg/app"
+	"gohub/pkg/file"
+	"gohub/pkg/helpers"
+	"gohub/pkg/logger"
+	"path/filepath"
+)
+
+// File 实现 email.Driver interface, 将邮件保存为 .eml 文件, 不会真正发送
+// 文件保存在 config["path"] 目录下, 可以直接用邮件客户端打开查看
+type File struct{}
+
+// Send 实现 email.Driver interface 的 Send 方法
+func (f *File) Send(email Email, config map[string]string) error {
+
+	content, err := newEmailPKG(email).Bytes()
+	if err != nil {
+		return err
+	}
+
+	// 文件名以时间开头, 方便按发送顺序查看
+	filename := fmt.Sprintf("%s_%s.eml",
+		app.TimenowInTimezone().Format("20060102_150405"),
+		helpers.RandomString(6),
+	)
+	path := filepath.Join(config["path"], filename)
+
+	if err := file.Put(content, path); err != nil {
+		return err
+	}
+
+	logger.DebugString("发送邮件[文件]", "保存成功", path)
+	return nil
+}
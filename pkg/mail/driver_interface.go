@@ -2,6 +2,7 @@
 package mail
 
 type Driver interface {
-	// 检查验证码
-	Send(email Email, config map[string]string) bool
+	// 发送邮件, 发送成功返回 nil
+	// 失败时尽量返回 SendError, 方便调用方判断失败的类型
+	Send(email Email, config map[string]string) error
 }
@@ -0,0 +1,22 @@
+package mail
+
+import (
+	"gohub/pkg/logger"
+)
+
+// Log 实现 email.Driver interface, 只将邮件内容写入日志, 不会真正发送
+type Log struct{}
+
+// Send 实现 email.Driver interface 的 Send 方法
+func (l *Log) Send(email Email, config map[string]string) error {
+	logger.InfoJSON("发送邮件[日志]", "邮件内容", map[string]interface{}{
+		"from":    email.From,
+		"to":      email.To,
+		"cc":      email.Cc,
+		"bcc":     email.Bcc,
+		"subject": email.Subject,
+		"text":    string(email.Text),
+		"html":    string(email.HTML),
+	})
+	return nil
+}
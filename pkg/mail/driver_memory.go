@@ -0,0 +1,35 @@
+package mail
+
+import "sync"
+
+// Memory 实现 email.Driver interface, 将邮件保存在内存中, 不会真正发送
+// 方便在测试中检查发送的邮件:
+//
+//	memory := mail.NewMailer().Driver.(*mail.Memory)
+//	emails := memory.Sent()
+type Memory struct {
+	mu     sync.Mutex
+	emails []Email
+}
+
+// Send 实现 email.Driver interface 的 Send 方法
+func (m *Memory) Send(email Email, config map[string]string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.emails = append(m.emails, email)
+	return nil
+}
+
+// Sent 返回已发送的邮件
+func (m *Memory) Sent() []Email {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Email(nil), m.emails...)
+}
+
+// Reset 清空已发送的邮件
+func (m *Memory) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.emails = nil
+}
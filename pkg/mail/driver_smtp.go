@@ -1,6 +1,7 @@
 package mail
 
 import (
+	"crypto/tls"
 	"fmt"
 	"gohub/pkg/logger"
 	"net/smtp"
@@ -12,35 +13,58 @@ import (
 type SMTP struct{}
 
 // Send 实现 email.Driver interface 的 Send 方法
-func (s *SMTP) Send(email Email, config map[string]string) bool {
+// config["encryption"] 支持:
+//   - ssl: 直接使用 TLS 连接, 一般为 465 端口
+//   - starttls: 先建立明文连接, 再升级为 TLS, 一般为 587 端口
+//   - none: 不强制加密, 如本地的 Mailhog (服务端支持 STARTTLS 时, net/smtp 仍会自动升级)
+func (s *SMTP) Send(email Email, config map[string]string) error {
 
-	e := emailPKG.NewEmail()
-
-	e.From = fmt.Sprintf("%v <%v>", email.From.Name, email.From.Address)
-	e.To = email.To
-	e.Bcc = email.Bcc
-	e.Cc = email.Cc
-	e.Subject = email.Subject
-	e.Text = email.Text
-	e.HTML = email.HTML
+	e := newEmailPKG(email)
 
 	logger.DebugJSON("发送邮件", "发送详情", e)
 
-	err := e.Send(
-		fmt.Sprintf("%v:%v", config["host"], config["port"]),
-		smtp.PlainAuth(
+	addr := fmt.Sprintf("%v:%v", config["host"], config["port"])
+
+	// 未配置用户名时不做认证, 方便对接 Mailhog 等本地服务
+	var auth smtp.Auth
+	if len(config["username"]) > 0 {
+		auth = smtp.PlainAuth(
 			"",
 			config["username"],
 			config["password"],
 			config["host"],
-		),
-	)
+		)
+	}
+
+	var err error
+	switch config["encryption"] {
+	case "ssl":
+		err = e.SendWithTLS(addr, auth, &tls.Config{ServerName: config["host"]})
+	case "starttls":
+		err = e.SendWithStartTLS(addr, auth, &tls.Config{ServerName: config["host"]})
+	default:
+		err = e.Send(addr, auth)
+	}
 
 	if err != nil {
-		logger.ErrorString("发送邮件", "发送出错", err.Error())
-		return false
+		return classifySMTPError(err)
 	}
 
 	logger.DebugString("发送邮件", "发送成功", "")
-	return true
+	return nil
+}
+
+// newEmailPKG 转换为 jordan-wright/email 的邮件对象, 用以发送或生成邮件原文
+func newEmailPKG(email Email) *emailPKG.Email {
+	e := emailPKG.NewEmail()
+
+	e.From = fmt.Sprintf("%v <%v>", email.From.Name, email.From.Address)
+	e.To = email.To
+	e.Bcc = email.Bcc
+	e.Cc = email.Cc
+	e.Subject = email.Subject
+	e.Text = email.Text
+	e.HTML = email.HTML
+
+	return e
 }
@@ -0,0 +1,55 @@
+package mail
+
+import (
+	"errors"
+	"gohub/pkg/notify"
+	"net"
+	"net/textproto"
+	"strings"
+)
+
+var (
+	ErrNoDriver          error = errors.New("未配置可用的邮件驱动")
+	ErrAuthFailed        error = errors.New("邮件服务器认证失败")
+	ErrConnectionFailed  error = errors.New("无法连接邮件服务器")
+	ErrRecipientRejected error = errors.New("收件人地址被拒收")
+)
+
+// SendError 发送失败的错误, Kind 为上面定义的错误类型之一, Err 为原始错误
+// 可以使用 errors.Is(err, mail.ErrAuthFailed) 判断失败的类型
+type SendError = notify.SendError
+
+// classifySMTPError 根据 SMTP 响应码和网络错误, 将 SMTP 发送的错误转换为 SendError
+func classifySMTPError(err error) error {
+
+	// 网络错误, 如连接被拒绝、超时、DNS 解析失败
+	var netErr net.Error
+	var opErr *net.OpError
+	if errors.As(err, &opErr) || errors.As(err, &netErr) {
+		return &SendError{Kind: ErrConnectionFailed, Err: err}
+	}
+
+	// 收件人地址格式不正确, 由 net/mail 解析地址时返回
+	if strings.HasPrefix(err.Error(), "mail: ") {
+		return &SendError{Kind: ErrRecipientRejected, Err: err}
+	}
+
+	// 服务器返回的 SMTP 错误码
+	var protoErr *textproto.Error
+	if errors.As(err, &protoErr) {
+		switch protoErr.Code {
+		case 530, 534, 535:
+			return &SendError{Kind: ErrAuthFailed, Err: err}
+		case 550, 551, 553:
+			return &SendError{Kind: ErrRecipientRejected, Err: err}
+		}
+		return err
+	}
+
+	// net/smtp 在未加密的连接上拒绝发送密码
+	if strings.Contains(err.Error(), "unencrypted connection") {
+		return &SendError{Kind: ErrAuthFailed, Err: err}
+	}
+
+	return err
+}
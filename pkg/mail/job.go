@@ -0,0 +1,28 @@
+package mail
+
+import "gohub/pkg/queue"
+
+// SendTemplateJob 使用模板发送邮件的队列任务, 用法:
+//
+//	queue.Dispatch(&mail.SendTemplateJob{
+//		To:       []string{email},
+//		Template: "welcome",
+//		Data:     map[string]interface{}{"name": name},
+//	})
+type SendTemplateJob struct {
+	To       []string
+	Template string
+	Data     map[string]interface{}
+}
+
+func init() {
+	queue.Register(&SendTemplateJob{})
+}
+
+func (job *SendTemplateJob) Name() string {
+	return "mail.send_template"
+}
+
+func (job *SendTemplateJob) Handle() error {
+	return NewMailer().SendTemplate(job.To, job.Template, job.Data)
+}
@@ -2,6 +2,7 @@ package mail
 
 import (
 	"gohub/pkg/config"
+	"gohub/pkg/logger"
 	"sync"
 )
 
@@ -22,21 +23,60 @@ type Email struct {
 
 type Mailer struct {
 	Driver Driver
+
+	// DriverName 驱动名称, 同时也是驱动配置信息在 mail 下的 key, 如 mail.smtp
+	DriverName string
 }
 
 var once sync.Once
 var internalMailer *Mailer
 
-// NewMailer 单例模式获取
+// drivers 已注册的驱动, key 为 mail.driver 配置项可选的值
+var drivers = map[string]Driver{
+	"smtp":   &SMTP{},
+	"log":    &Log{},
+	"file":   &File{},
+	"memory": &Memory{},
+}
+
+// RegisterDriver 注册自定义驱动, 需在第一次调用 NewMailer 之前注册
+// 驱动的配置信息放在 mail.{name} 下
+func RegisterDriver(name string, driver Driver) {
+	drivers[name] = driver
+}
+
+// NewMailer 单例模式获取, 使用 mail.driver 配置的驱动
 func NewMailer() *Mailer {
 	once.Do(func() {
+		name := config.GetString("mail.driver")
+		driver, ok := drivers[name]
+		if !ok {
+			logger.ErrorString("发送邮件", "初始化驱动", "不支持的驱动: "+name)
+		}
 		internalMailer = &Mailer{
-			Driver: &SMTP{},
+			Driver:     driver,
+			DriverName: name,
 		}
 	})
 	return internalMailer
 }
 
-func (mailer *Mailer) Send(email Email) bool {
-	return mailer.Driver.Send(email, config.GetStringMapString("mail.smtp"))
+// Send 使用配置的驱动发送邮件, 驱动会收到 mail.{驱动名称} 下的配置信息
+// 发送失败时记录错误日志, 并返回错误, 可以使用 errors.Is 判断失败的类型
+func (mailer *Mailer) Send(email Email) error {
+	if mailer.Driver == nil {
+		logger.ErrorString("发送邮件", "发送失败", "未配置可用的驱动: "+mailer.DriverName)
+		return ErrNoDriver
+	}
+
+	err := mailer.Driver.Send(email, config.GetStringMapString("mail."+mailer.DriverName))
+	if err != nil {
+		logger.ErrorJSON("发送邮件", "发送失败", map[string]interface{}{
+			"driver":  mailer.DriverName,
+			"to":      email.To,
+			"subject": email.Subject,
+			"error":   err.Error(),
+		})
+	}
+	return err
 }
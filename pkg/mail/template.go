@@ -0,0 +1,146 @@
+package mail
+
+import (
+	"bytes"
+	"errors"
+	"gohub/pkg/app"
+	"gohub/pkg/config"
+	"gohub/pkg/logger"
+	"gohub/resources"
+	"html"
+	"html/template"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// 默认的邮件模板位于 resources/mails 目录, 编译时嵌入二进制文件, 部署时无需携带模板文件
+// mail.template_path 目录下的同名文件会覆盖默认模板, 修改文案无需重新编译
+// 所有模板共用 layout.html 布局, 每个模板需定义 subject 和 content 两个区块, 如 verifycode.html:
+//
+//	{{define "subject"}}您的验证码{{end}}
+//	{{define "content"}}<p>您的验证码是: {{.code}}</p>{{end}}
+//
+// 模板中除了调用方传参的变量, 还可以使用 app_name、app_url 和 year
+
+// Render 渲染名为 name 的邮件模板, 返回主题、HTML 内容以及据此生成的纯文本内容
+func Render(name string, data map[string]interface{}) (subject string, htmlBody []byte, textBody []byte, err error) {
+
+	tmpl, err := parseTemplates("layout.html", name+".html")
+	if err != nil {
+		return
+	}
+
+	// 公共变量, 调用方传参的同名变量优先
+	vars := map[string]interface{}{
+		"app_name": config.GetString("app.name"),
+		"app_url":  config.GetString("app.url"),
+		"year":     app.TimenowInTimezone().Year(),
+	}
+	for key, value := range data {
+		vars[key] = value
+	}
+
+	var buf bytes.Buffer
+	if err = tmpl.ExecuteTemplate(&buf, "subject", vars); err != nil {
+		return
+	}
+	subject = strings.TrimSpace(html.UnescapeString(buf.String()))
+
+	buf.Reset()
+	if err = tmpl.ExecuteTemplate(&buf, "layout.html", vars); err != nil {
+		return
+	}
+	htmlBody = buf.Bytes()
+	textBody = htmlToText(htmlBody)
+
+	return
+}
+
+// parseTemplates 解析模板文件, 模板名为文件名, 与 template.ParseFiles 一致
+func parseTemplates(filenames ...string) (*template.Template, error) {
+	var tmpl *template.Template
+	for _, filename := range filenames {
+		content, err := readTemplate(filename)
+		if err != nil {
+			return nil, err
+		}
+
+		var t *template.Template
+		if tmpl == nil {
+			tmpl = template.New(filename)
+			t = tmpl
+		} else {
+			t = tmpl.New(filename)
+		}
+		if _, err := t.Parse(string(content)); err != nil {
+			return nil, err
+		}
+	}
+	return tmpl, nil
+}
+
+// readTemplate 读取模板文件, 优先使用 mail.template_path 目录下的文件, 不存在时使用嵌入的默认模板
+func readTemplate(filename string) ([]byte, error) {
+	if dir := config.GetString("mail.template_path"); len(dir) > 0 {
+		content, err := os.ReadFile(filepath.Join(dir, filename))
+		if err == nil || !errors.Is(err, fs.ErrNotExist) {
+			return content, err
+		}
+	}
+	return fs.ReadFile(resources.Mails, "mails/"+filename)
+}
+
+// SendTemplate 使用模板发送邮件, 发件人使用 mail.from 的配置, 用法:
+//
+//	mail.NewMailer().SendTemplate([]string{email}, "verifycode", map[string]interface{}{
+//		"code": code,
+//	})
+func (mailer *Mailer) SendTemplate(to []string, name string, data map[string]interface{}) error {
+	subject, htmlBody, textBody, err := Render(name, data)
+	if err != nil {
+		logger.ErrorString("发送邮件", "渲染模板 "+name+" 出错", err.Error())
+		return err
+	}
+
+	return mailer.Send(Email{
+		From: From{
+			Address: config.GetString("mail.from.address"),
+			Name:    config.GetString("mail.from.name"),
+		},
+		To:      to,
+		Subject: subject,
+		Text:    textBody,
+		HTML:    htmlBody,
+	})
+}
+
+var (
+	htmlHiddenRegexp     = regexp.MustCompile(`(?is)<(head|style|script)[^>]*>.*?</(head|style|script)>`)
+	htmlLinkRegexp       = regexp.MustCompile(`(?is)<a\s[^>]*href="([^"]*)"[^>]*>(.*?)</a>`)
+	htmlLineBreakRegexp  = regexp.MustCompile(`(?i)<br\s*/?>|</(p|div|h[1-6]|li|tr|table)>`)
+	htmlTagRegexp        = regexp.MustCompile(`<[^>]+>`)
+	textBlankLinesRegexp = regexp.MustCompile(`\n{3,}`)
+)
+
+// htmlToText 将 HTML 内容转换为纯文本, 作为不支持 HTML 的邮件客户端的备选内容
+func htmlToText(htmlBody []byte) []byte {
+	text := htmlHiddenRegexp.ReplaceAllString(string(htmlBody), "")
+	// 链接保留地址, 格式为: 文字 (地址)
+	text = htmlLinkRegexp.ReplaceAllString(text, "$2 ($1)")
+	text = htmlLineBreakRegexp.ReplaceAllString(text, "\n")
+	text = htmlTagRegexp.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+
+	// 去掉 HTML 缩进带来的行首行尾空白
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	text = strings.Join(lines, "\n")
+	text = textBlankLinesRegexp.ReplaceAllString(text, "\n\n")
+
+	return []byte(strings.TrimSpace(text) + "\n")
+}
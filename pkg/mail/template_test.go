@@ -0,0 +1,77 @@
+package mail
+
+import (
+	"gohub/pkg/config"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestHtmlToText(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{"paragraphs", "<p>您好:</p>\n  <p>验证码是 123456</p>", "您好:\n\n验证码是 123456\n"},
+		{"line break", "第一行<br>第二行<br/>第三行", "第一行\n第二行\n第三行\n"},
+		{"link", `<a href="https://example.com/reset?token=1&amp;a=2">重置密码</a>`, "重置密码 (https://example.com/reset?token=1&a=2)\n"},
+		{"hidden", "<head><title>标题</title><style>p { color: red; }</style></head><p>正文</p>", "正文\n"},
+		{"entities", "<p>&lt;Gohub&gt; &amp; 朋友</p>", "<Gohub> & 朋友\n"},
+		{"blank lines", "<div>a</div>\n\n\n\n<div>b</div>", "a\n\nb\n"},
+	}
+	for _, tt := range tests {
+		if got := string(htmlToText([]byte(tt.html))); got != tt.want {
+			t.Errorf("%s: htmlToText(%q) = %q, want %q", tt.name, tt.html, got, tt.want)
+		}
+	}
+}
+
+func TestRenderEmbeddedTemplate(t *testing.T) {
+	config.Set("app.name", "Gohub")
+	config.Set("mail.template_path", filepath.Join(t.TempDir(), "missing"))
+
+	subject, htmlBody, textBody, err := Render("verifycode", map[string]interface{}{
+		"code":        "123456",
+		"expire_time": 15,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subject != "您的验证码" {
+		t.Errorf("subject = %q, want %q", subject, "您的验证码")
+	}
+	if !strings.Contains(string(htmlBody), `<p class="code">123456</p>`) {
+		t.Errorf("html body does not contain the code:\n%s", htmlBody)
+	}
+	if !strings.Contains(string(textBody), "123456") || strings.Contains(string(textBody), "<p") {
+		t.Errorf("text body = %q, want plain text with the code", textBody)
+	}
+}
+
+func TestRenderOverrideTemplate(t *testing.T) {
+	dir := t.TempDir()
+	config.Set("mail.template_path", dir)
+
+	// 只覆盖 verifycode.html, layout.html 仍使用默认模板
+	override := `{{define "subject"}}{{.app_name}} 验证码 {{.code}}{{end}}{{define "content"}}<p>自定义 {{.code}}</p>{{end}}`
+	if err := os.WriteFile(filepath.Join(dir, "verifycode.html"), []byte(override), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	subject, htmlBody, _, err := Render("verifycode", map[string]interface{}{"code": "654321", "app_name": "Demo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subject != "Demo 验证码 654321" {
+		t.Errorf("subject = %q, want %q", subject, "Demo 验证码 654321")
+	}
+	if !strings.Contains(string(htmlBody), "<p>自定义 654321</p>") || !strings.Contains(string(htmlBody), "<!DOCTYPE html>") {
+		t.Errorf("html body does not use the override inside the default layout:\n%s", htmlBody)
+	}
+
+	if _, _, _, err := Render("not_exists", nil); err == nil {
+		t.Error("Render(not_exists) error = nil, want an error")
+	}
+}
@@ -0,0 +1,62 @@
+// Package migrate 处理数据库迁移
+package migrate
+
+import (
+	"database/sql"
+	"sort"
+
+	"gorm.io/gorm"
+)
+
+// migrationFunc 定义 up 和 down 回调方法的类型, 返回错误时迁移中止, 不会记录到 migrations 表
+type migrationFunc func(gorm.Migrator, *sql.DB) error
+
+// migrationFiles 所有的迁移文件数组
+var migrationFiles []MigrationFile
+
+// MigrationFile 代表着单个迁移文件
+type MigrationFile struct {
+	Up       migrationFunc
+	Down     migrationFunc
+	FileName string
+}
+
+// Add 新增一个迁移文件, 所有的迁移文件都需要调用此方法来注册
+// name 使用 2006_01_02_150405_ 时间戳前缀, 迁移按 name 的顺序执行
+func Add(name string, up migrationFunc, down migrationFunc) {
+	migrationFiles = append(migrationFiles, MigrationFile{
+		FileName: name,
+		Up:       up,
+		Down:     down,
+	})
+}
+
+// getMigrationFile 通过迁移文件的名称来获取到 MigrationFile 对象, 第二个返回值表示是否找到
+func getMigrationFile(name string) (MigrationFile, bool) {
+	for _, mfile := range migrationFiles {
+		if name == mfile.FileName {
+			return mfile, true
+		}
+	}
+	return MigrationFile{}, false
+}
+
+// sortedMigrationFiles 按时间戳前缀排序后的所有迁移文件
+func sortedMigrationFiles() []MigrationFile {
+	files := make([]MigrationFile, len(migrationFiles))
+	copy(files, migrationFiles)
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].FileName < files[j].FileName
+	})
+	return files
+}
+
+// isNotMigrated 判断迁移是否已执行
+func (mfile MigrationFile) isNotMigrated(migrations []Migration) bool {
+	for _, migration := range migrations {
+		if migration.Migration == mfile.FileName {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,21 @@
+// Package notify 邮件、短信等通知渠道共用的类型
+package notify
+
+// SendError 发送失败的错误, Kind 为各渠道定义的错误类型, 如 mail.ErrAuthFailed、sms.ErrInvalidPhone, Err 为原始错误
+// 可以使用 errors.Is(err, sms.ErrInvalidPhone) 判断失败的类型, errors.As 取出原始错误
+type SendError struct {
+	Kind error
+	Err  error
+}
+
+func (e *SendError) Error() string {
+	return e.Kind.Error() + ": " + e.Err.Error()
+}
+
+func (e *SendError) Is(target error) bool {
+	return target == e.Kind
+}
+
+func (e *SendError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,39 @@
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestSendError(t *testing.T) {
+	errAuthFailed := errors.New("认证失败")
+	errOther := errors.New("其他错误")
+	cause := errors.New("535 authentication failed")
+
+	err := fmt.Errorf("发送失败: %w", &SendError{Kind: errAuthFailed, Err: cause})
+
+	if got, want := err.Error(), "发送失败: 认证失败: 535 authentication failed"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+
+	tests := []struct {
+		name   string
+		target error
+		want   bool
+	}{
+		{"kind", errAuthFailed, true},
+		{"cause", cause, true},
+		{"other", errOther, false},
+	}
+	for _, tt := range tests {
+		if got := errors.Is(err, tt.target); got != tt.want {
+			t.Errorf("%s: errors.Is() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	var sendErr *SendError
+	if !errors.As(err, &sendErr) || sendErr.Kind != errAuthFailed {
+		t.Errorf("errors.As() = %v, want a SendError with Kind %v", sendErr, errAuthFailed)
+	}
+}
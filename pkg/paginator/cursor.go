@@ -0,0 +1,199 @@
+package paginator
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"gohub/pkg/config"
+	"gohub/pkg/logger"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrInvalidCursor 客户端传参的游标无法解析, 或与当前的排序参数不匹配
+var ErrInvalidCursor = errors.New("分页游标无效, 请从第一页重新加载")
+
+// CursorPaging 游标分页数据
+type CursorPaging struct {
+	PerPage     int    `json:"per_page"`      // 每页条数
+	HasMore     bool   `json:"has_more"`      // 是否还有下一页
+	NextCursor  string `json:"next_cursor"`   // 下一页的游标
+	NextPageURL string `json:"next_page_url"` // 下一页的链接
+}
+
+// cursor 游标内容, 记录上一页最后一条数据的排序字段值和 ID
+// 编码后对客户端是不透明的字符串
+type cursor struct {
+	Sort  string          `json:"s"`
+	Order string          `json:"o"`
+	Value json.RawMessage `json:"v"`
+	ID    uint64          `json:"id"`
+}
+
+// CursorPaginate 游标分页 (keyset pagination)
+// 与 Paginate 的 OFFSET 分页不同, 游标分页使用上一页最后一条数据的 (排序字段, id) 作为查询条件,
+// 无论翻到多深, 查询都能走索引, 适合大表和无限滚动的场景. 代价是无法跳页, 也无法获取总页数
+// 参数同 Paginate, 第一页不传 cursor 参数, 之后使用返回的 next_cursor 或 next_page_url 加载下一页
+// 用法:
+//
+//	var users []user.User
+//	paging, err := paginator.CursorPaginate(c, database.DB.Model(user.User{}), &users, app.V1URL("users"), perPage)
+func CursorPaginate(c *gin.Context, db *gorm.DB, data interface{}, baseURL string, perPage int, sortable ...string) (CursorPaging, error) {
+
+	// 复用 Paginator 对 per_page、sort、order 参数的读取和白名单校验
+	p := &Paginator{
+		BaseURL: baseURL,
+		query:   db,
+		ctx:     c,
+	}
+	p.PerPage = p.getPerPage(perPage)
+	p.Order = p.getOrder()
+	p.Sort = p.getSort(sortable)
+
+	// 解析模型, 用以读取排序字段和 ID 的值
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(data); err != nil {
+		logger.LogIf(err)
+		return CursorPaging{}, err
+	}
+	sortField := stmt.Schema.LookUpField(p.Sort)
+	idField := stmt.Schema.LookUpField("id")
+	if sortField == nil || idField == nil {
+		return CursorPaging{}, errors.New("模型缺少排序字段 " + p.Sort + " 或 id 字段")
+	}
+
+	// 有游标时, 从游标位置之后开始读取
+	query := p.query
+	if encoded := c.Query(config.Get("paging.url_query_cursor")); len(encoded) > 0 {
+		cur, err := decodeCursor(encoded)
+		if err != nil || cur.Sort != p.Sort || cur.Order != p.Order {
+			return CursorPaging{}, ErrInvalidCursor
+		}
+		query = p.whereAfterCursor(query, cur, sortField.FieldType)
+		if query == nil {
+			return CursorPaging{}, ErrInvalidCursor
+		}
+	}
+
+	// 多读取一条, 用以判断是否还有下一页
+	err := query.
+		Preload(clause.Associations).  // 读取关联
+		Order(p.Sort + " " + p.Order). // 排序
+		Order("id " + p.Order).        // 排序字段相同时, 以 id 保证顺序稳定
+		Limit(p.PerPage + 1).
+		Find(data).
+		Error
+
+	// 数据库出错
+	if err != nil {
+		logger.LogIf(err)
+		return CursorPaging{}, err
+	}
+
+	paging := CursorPaging{PerPage: p.PerPage}
+
+	rows := reflect.Indirect(reflect.ValueOf(data))
+	if rows.Len() <= p.PerPage {
+		return paging, nil
+	}
+
+	// 去掉多读取的那一条, 以本页最后一条数据生成下一页的游标
+	rows.SetLen(p.PerPage)
+	last := reflect.Indirect(rows.Index(p.PerPage - 1))
+	sortValue, _ := sortField.ValueOf(c, last)
+	idValue, _ := idField.ValueOf(c, last)
+
+	paging.HasMore = true
+	paging.NextCursor = encodeCursor(p.Sort, p.Order, sortValue, cast.ToUint64(idValue))
+	paging.NextPageURL = p.getCursorLink(paging.NextCursor)
+
+	return paging, nil
+}
+
+// whereAfterCursor 拼接游标条件, 正序时读取游标之后更大的数据, 倒序时读取更小的数据
+// 游标的值无法转换为排序字段的类型时返回 nil
+func (p Paginator) whereAfterCursor(query *gorm.DB, cur cursor, fieldType reflect.Type) *gorm.DB {
+	operator := ">"
+	if p.Order == "desc" {
+		operator = "<"
+	}
+
+	// 按 id 排序时, 只需要比较 id
+	if p.Sort == "id" {
+		return query.Where("id "+operator+" ?", cur.ID)
+	}
+
+	// 将游标里的值还原为排序字段的类型, 如 time.Time
+	value := reflect.New(fieldType)
+	if err := json.Unmarshal(cur.Value, value.Interface()); err != nil {
+		return nil
+	}
+	v := value.Elem().Interface()
+
+	// 排序字段 sort 已经过白名单校验, 可以放心拼接
+	return query.Where(
+		"("+p.Sort+" "+operator+" ? OR ("+p.Sort+" = ? AND id "+operator+" ?))",
+		v, v, cur.ID,
+	)
+}
+
+// 拼接游标分页的链接
+func (p Paginator) getCursorLink(next string) string {
+	query := url.Values{}
+	query.Set(config.Get("paging.url_query_cursor"), next)
+	query.Set(config.Get("paging.url_query_sort"), p.Sort)
+	query.Set(config.Get("paging.url_query_order"), p.Order)
+	query.Set(config.Get("paging.url_query_per_page"), cast.ToString(p.PerPage))
+
+	if strings.Contains(p.BaseURL, "?") {
+		return p.BaseURL + "&" + query.Encode()
+	}
+	return p.BaseURL + "?" + query.Encode()
+}
+
+// encodeCursor 将游标编码为 URL 安全的字符串
+func encodeCursor(sort, order string, value interface{}, id uint64) string {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		logger.LogIf(err)
+		return ""
+	}
+
+	b, err := json.Marshal(cursor{
+		Sort:  sort,
+		Order: order,
+		Value: raw,
+		ID:    id,
+	})
+	if err != nil {
+		logger.LogIf(err)
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// IsValidCursor 游标格式是否正确, 空值表示第一页, 也是合法的
+// 游标是否与当前的排序参数匹配, 由 CursorPaginate 检查
+func IsValidCursor(encoded string) bool {
+	if len(encoded) == 0 {
+		return true
+	}
+	_, err := decodeCursor(encoded)
+	return err == nil
+}
+
+// decodeCursor 解析客户端传参的游标
+func decodeCursor(encoded string) (cur cursor, err error) {
+	b, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(b, &cur)
+	return
+}
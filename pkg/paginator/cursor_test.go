@@ -0,0 +1,163 @@
+package paginator
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"gohub/pkg/config"
+	"gohub/pkg/logger"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+type article struct {
+	ID        uint64 `gorm:"primaryKey"`
+	Title     string
+	CreatedAt time.Time
+}
+
+func TestMain(m *testing.M) {
+	logger.Logger = zap.NewNop()
+	gin.SetMode(gin.TestMode)
+	config.Set("paging.url_query_page", "page")
+	config.Set("paging.url_query_sort", "sort")
+	config.Set("paging.url_query_order", "order")
+	config.Set("paging.url_query_per_page", "per_page")
+	config.Set("paging.url_query_cursor", "cursor")
+	config.Set("paging.perpage", 10)
+	config.Set("paging.max_perpage", 100)
+	os.Exit(m.Run())
+}
+
+func TestEncodeDecodeCursor(t *testing.T) {
+	createdAt := time.Date(2022, 6, 20, 16, 47, 23, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		sort  string
+		order string
+		value interface{}
+		id    uint64
+		want  string // 游标中 v 的 JSON
+	}{
+		{"id", "id", "asc", uint64(42), 42, "42"},
+		{"time", "created_at", "desc", createdAt, 7, `"2022-06-20T16:47:23Z"`},
+		{"string", "title", "asc", "你好", 3, `"你好"`},
+	}
+	for _, tt := range tests {
+		encoded := encodeCursor(tt.sort, tt.order, tt.value, tt.id)
+		if !IsValidCursor(encoded) {
+			t.Errorf("%s: IsValidCursor(%q) = false, want true", tt.name, encoded)
+		}
+		if _, err := url.ParseQuery("cursor=" + encoded); err != nil {
+			t.Errorf("%s: cursor %q is not URL safe: %v", tt.name, encoded, err)
+		}
+
+		cur, err := decodeCursor(encoded)
+		if err != nil {
+			t.Errorf("%s: decodeCursor(%q) error = %v", tt.name, encoded, err)
+			continue
+		}
+		if cur.Sort != tt.sort || cur.Order != tt.order || cur.ID != tt.id || string(cur.Value) != tt.want {
+			t.Errorf("%s: decodeCursor() = {%s %s %s %d}, want {%s %s %s %d}",
+				tt.name, cur.Sort, cur.Order, cur.Value, cur.ID, tt.sort, tt.order, tt.want, tt.id)
+		}
+	}
+}
+
+func TestIsValidCursor(t *testing.T) {
+	valid := encodeCursor("id", "asc", 1, 1)
+
+	tests := []struct {
+		name    string
+		encoded string
+		want    bool
+	}{
+		{"empty", "", true},
+		{"valid", valid, true},
+		{"not base64", "!!!", false},
+		{"padded base64", base64.URLEncoding.EncodeToString([]byte(`{"id":1}`)), false},
+		{"not json", base64.RawURLEncoding.EncodeToString([]byte("id=1")), false},
+	}
+	for _, tt := range tests {
+		if got := IsValidCursor(tt.encoded); got != tt.want {
+			t.Errorf("%s: IsValidCursor(%q) = %v, want %v", tt.name, tt.encoded, got, tt.want)
+		}
+	}
+}
+
+// newCursorContext 创建请求参数为 query 的 gin.Context
+func newCursorContext(query url.Values) *gin.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("GET", "/articles?"+query.Encode(), nil)
+	return c
+}
+
+func TestCursorPaginate(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: gormlogger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(&article{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// 7 条数据, created_at 有重复, 用以验证相同排序值时以 id 翻页
+	base := time.Date(2022, 6, 20, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 7; i++ {
+		db.Create(&article{Title: "a", CreatedAt: base.Add(time.Duration(i/2) * time.Hour)})
+	}
+
+	tests := []struct {
+		sort  string
+		order string
+		want  []uint64
+	}{
+		{"id", "asc", []uint64{1, 2, 3, 4, 5, 6, 7}},
+		{"id", "desc", []uint64{7, 6, 5, 4, 3, 2, 1}},
+		{"created_at", "asc", []uint64{1, 2, 3, 4, 5, 6, 7}},
+		{"created_at", "desc", []uint64{7, 6, 5, 4, 3, 2, 1}},
+	}
+	for _, tt := range tests {
+		var got []uint64
+		query := url.Values{"sort": {tt.sort}, "order": {tt.order}, "per_page": {"3"}, "cursor": {""}}
+		for page := 0; page < 10; page++ {
+			var articles []article
+			paging, err := CursorPaginate(newCursorContext(query), db.Model(&article{}), &articles, "/articles", 10)
+			if err != nil {
+				t.Fatalf("%s %s: CursorPaginate() error = %v", tt.sort, tt.order, err)
+			}
+			for _, a := range articles {
+				got = append(got, a.ID)
+			}
+			if !paging.HasMore {
+				break
+			}
+			query.Set("cursor", paging.NextCursor)
+		}
+
+		if gotJSON, wantJSON := mustJSON(got), mustJSON(tt.want); gotJSON != wantJSON {
+			t.Errorf("%s %s: pages = %s, want %s", tt.sort, tt.order, gotJSON, wantJSON)
+		}
+	}
+
+	// 游标与排序参数不匹配
+	var articles []article
+	query := url.Values{"sort": {"created_at"}, "cursor": {encodeCursor("id", "asc", 1, 1)}}
+	if _, err := CursorPaginate(newCursorContext(query), db.Model(&article{}), &articles, "/articles", 3); err != ErrInvalidCursor {
+		t.Errorf("CursorPaginate() with mismatched cursor error = %v, want %v", err, ErrInvalidCursor)
+	}
+}
+
+func mustJSON(v interface{}) string {
+	b, _ := json.Marshal(v)
+	return string(b)
+}
@@ -0,0 +1,229 @@
+// Package paginator 处理分页逻辑
+package paginator
+
+import (
+	"fmt"
+	"gohub/pkg/config"
+	"gohub/pkg/logger"
+	"math"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Paging 分页数据
+type Paging struct {
+	CurrentPage int    `json:"current_page"`  // 当前页
+	PerPage     int    `json:"per_page"`      // 每页条数
+	TotalPage   int    `json:"total_page"`    // 总页数
+	TotalCount  int64  `json:"total_count"`   // 总条数
+	NextPageURL string `json:"next_page_url"` // 下一页的链接
+	PrevPageURL string `json:"prev_page_url"` // 上一页的链接
+}
+
+// Paginator 分页操作类
+type Paginator struct {
+	BaseURL    string // 用以拼接 URL
+	PerPage    int    // 每页条数
+	Page       int    // 当前页
+	Offset     int    // 数据库读取数据时 Offset 的值
+	TotalCount int64  // 总条数
+	TotalPage  int    // 总页数 = TotalCount/PerPage
+	Sort       string // 排序规则
+	Order      string // 排序顺序
+
+	query *gorm.DB     // db query 句柄
+	ctx   *gin.Context // gin context, 方便调用
+}
+
+// DefaultSortable 未指定排序白名单时, 允许排序的字段
+var DefaultSortable = []string{"id", "created_at", "updated_at"}
+
+// Paginate 分页
+// c —— gin.context 用来获取分页的 URL 参数
+// db —— GORM 查询句柄, 用以查询数据集和获取数据总数
+// baseURL —— 用以分页链接
+// data —— 模型数组, 传址获取数据
+// perPage —— 每页条数, 优先从 url 参数里取, 否则使用 perPage 的值
+// sortable —— 允许排序的字段白名单, 不传时使用 DefaultSortable
+// 用法:
+//
+//	query := database.DB.Model(user.User{}).Where("category_id = ?", cid)
+//	var users []user.User
+//	paging := paginator.Paginate(c, query, &users, app.V1URL(database.TableName(&user.User{})), perPage)
+func Paginate(c *gin.Context, db *gorm.DB, data interface{}, baseURL string, perPage int, sortable ...string) Paging {
+
+	// 初始化 Paginator 实例
+	p := &Paginator{
+		query: db,
+		ctx:   c,
+	}
+	p.initProperties(perPage, baseURL, sortable)
+
+	// 查询数据库
+	err := p.query.
+		Preload(clause.Associations).  // 读取关联
+		Order(p.Sort + " " + p.Order). // 排序
+		Limit(p.PerPage).
+		Offset(p.Offset).
+		Find(data).
+		Error
+
+	// 数据库出错
+	if err != nil {
+		logger.LogIf(err)
+		return Paging{}
+	}
+
+	return Paging{
+		CurrentPage: p.Page,
+		PerPage:     p.PerPage,
+		TotalPage:   p.TotalPage,
+		TotalCount:  p.TotalCount,
+		NextPageURL: p.getNextPageURL(),
+		PrevPageURL: p.getPrevPageURL(),
+	}
+}
+
+// 初始化分页必须用到的属性, 基于这些属性查询数据库
+func (p *Paginator) initProperties(perPage int, baseURL string, sortable []string) {
+
+	p.BaseURL = p.formatBaseURL(baseURL)
+	p.PerPage = p.getPerPage(perPage)
+
+	// 排序参数 (控制器中已验证过这些参数, 可放心使用, 这里再以白名单兜底)
+	p.Order = p.getOrder()
+	p.Sort = p.getSort(sortable)
+
+	p.TotalCount = p.getTotalCount()
+	p.TotalPage = p.getTotalPage()
+	p.Page = p.getCurrentPage()
+	p.Offset = (p.Page - 1) * p.PerPage
+}
+
+// getOrder 排序顺序, 只允许 asc 和 desc
+func (p Paginator) getOrder() string {
+	order := strings.ToLower(p.ctx.DefaultQuery(config.Get("paging.url_query_order"), "asc"))
+	if order != "asc" && order != "desc" {
+		return "asc"
+	}
+	return order
+}
+
+// getSort 排序字段, 必须在白名单中, 否则使用 id 排序
+func (p Paginator) getSort(sortable []string) string {
+	if len(sortable) == 0 {
+		sortable = DefaultSortable
+	}
+
+	sort := p.ctx.DefaultQuery(config.Get("paging.url_query_sort"), "id")
+	for _, column := range sortable {
+		if sort == column {
+			return sort
+		}
+	}
+	return "id"
+}
+
+func (p Paginator) getPerPage(perPage int) int {
+	// 优先使用请求 per_page 参数
+	queryPerpage := p.ctx.Query(config.Get("paging.url_query_per_page"))
+	if len(queryPerpage) > 0 {
+		perPage = cast.ToInt(queryPerpage)
+	}
+
+	// 没有传参, 使用默认
+	if perPage <= 0 {
+		perPage = config.GetInt("paging.perpage")
+	}
+
+	// 不允许超过上限
+	if maxPerPage := config.GetInt("paging.max_perpage"); maxPerPage > 0 && perPage > maxPerPage {
+		perPage = maxPerPage
+	}
+
+	return perPage
+}
+
+// getCurrentPage 返回当前页码
+func (p Paginator) getCurrentPage() int {
+	// 优先取用户请求的 page
+	page := cast.ToInt(p.ctx.Query(config.Get("paging.url_query_page")))
+	if page <= 0 {
+		// 默认为 1
+		page = 1
+	}
+	// TotalPage 等于 0, 意味着数据不够分页
+	if p.TotalPage == 0 {
+		return 0
+	}
+	// 请求页数大于总页数, 返回总页数
+	if page > p.TotalPage {
+		return p.TotalPage
+	}
+	return page
+}
+
+// getTotalCount 返回的是数据库里的条数
+func (p *Paginator) getTotalCount() int64 {
+	var count int64
+	if err := p.query.Count(&count).Error; err != nil {
+		return 0
+	}
+	return count
+}
+
+// getTotalPage 计算总页数
+func (p Paginator) getTotalPage() int {
+	if p.TotalCount == 0 {
+		return 0
+	}
+	nums := int64(math.Ceil(float64(p.TotalCount) / float64(p.PerPage)))
+	if nums == 0 {
+		nums = 1
+	}
+	return int(nums)
+}
+
+// 兼容 URL 带与不带 `?` 的情况
+func (p *Paginator) formatBaseURL(baseURL string) string {
+	if strings.Contains(baseURL, "?") {
+		baseURL = baseURL + "&" + config.Get("paging.url_query_page") + "="
+	} else {
+		baseURL = baseURL + "?" + config.Get("paging.url_query_page") + "="
+	}
+	return baseURL
+}
+
+// 拼接分页链接
+func (p Paginator) getPageLink(page int) string {
+	return fmt.Sprintf("%v%v&%s=%s&%s=%s&%s=%v",
+		p.BaseURL,
+		page,
+		config.Get("paging.url_query_sort"),
+		p.Sort,
+		config.Get("paging.url_query_order"),
+		p.Order,
+		config.Get("paging.url_query_per_page"),
+		p.PerPage,
+	)
+}
+
+// getNextPageURL 返回下一页的链接
+func (p Paginator) getNextPageURL() string {
+	if p.TotalPage > p.Page {
+		return p.getPageLink(p.Page + 1)
+	}
+	return ""
+}
+
+// getPrevPageURL 返回上一页的链接
+func (p Paginator) getPrevPageURL() string {
+	if p.Page <= 1 || p.Page > p.TotalPage {
+		return ""
+	}
+	return p.getPageLink(p.Page - 1)
+}
@@ -0,0 +1,98 @@
+package paginator
+
+import (
+	"net/url"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// openArticles 创建有 count 条数据的 articles 表
+func openArticles(t *testing.T, count int) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: gormlogger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(&article{}); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < count; i++ {
+		db.Create(&article{Title: "a"})
+	}
+	return db
+}
+
+func TestPaginate(t *testing.T) {
+	db := openArticles(t, 25)
+
+	tests := []struct {
+		name        string
+		query       url.Values
+		perPage     int
+		wantPage    int
+		wantPerPage int
+		wantTotal   int
+		wantFirstID uint64
+		wantRows    int
+	}{
+		{"first page", url.Values{}, 10, 1, 10, 3, 1, 10},
+		{"last page", url.Values{"page": {"3"}}, 10, 3, 10, 3, 21, 5},
+		{"page beyond last is clamped", url.Values{"page": {"9"}}, 10, 3, 10, 3, 21, 5},
+		{"page zero is clamped", url.Values{"page": {"0"}}, 10, 1, 10, 3, 1, 10},
+		{"negative page is clamped", url.Values{"page": {"-2"}}, 10, 1, 10, 3, 1, 10},
+		{"invalid page is clamped", url.Values{"page": {"abc"}}, 10, 1, 10, 3, 1, 10},
+		{"per_page overrides argument", url.Values{"per_page": {"5"}}, 10, 1, 5, 5, 1, 5},
+		{"per_page above max is capped", url.Values{"per_page": {"1000"}}, 10, 1, 100, 1, 1, 25},
+		{"zero per_page uses config default", url.Values{"per_page": {"0"}}, 0, 1, 10, 3, 1, 10},
+		{"desc order", url.Values{"order": {"desc"}}, 10, 1, 10, 3, 25, 10},
+		{"unknown sort falls back to id", url.Values{"sort": {"title; drop table articles"}}, 10, 1, 10, 3, 1, 10},
+	}
+	for _, tt := range tests {
+		var articles []article
+		paging := Paginate(newCursorContext(tt.query), db.Model(&article{}), &articles, "/articles", tt.perPage)
+
+		if paging.CurrentPage != tt.wantPage || paging.PerPage != tt.wantPerPage || paging.TotalPage != tt.wantTotal {
+			t.Errorf("%s: page = %d, per_page = %d, total_page = %d, want %d, %d, %d",
+				tt.name, paging.CurrentPage, paging.PerPage, paging.TotalPage, tt.wantPage, tt.wantPerPage, tt.wantTotal)
+		}
+		if paging.TotalCount != 25 {
+			t.Errorf("%s: total_count = %d, want 25", tt.name, paging.TotalCount)
+		}
+		if len(articles) != tt.wantRows || len(articles) > 0 && articles[0].ID != tt.wantFirstID {
+			t.Errorf("%s: got %d rows starting at %v, want %d rows starting at %d", tt.name, len(articles), articles, tt.wantRows, tt.wantFirstID)
+		}
+	}
+}
+
+func TestPaginateLinks(t *testing.T) {
+	db := openArticles(t, 25)
+
+	tests := []struct {
+		page     string
+		wantPrev string
+		wantNext string
+	}{
+		{"1", "", "/articles?page=2&sort=id&order=asc&per_page=10"},
+		{"2", "/articles?page=1&sort=id&order=asc&per_page=10", "/articles?page=3&sort=id&order=asc&per_page=10"},
+		{"3", "/articles?page=2&sort=id&order=asc&per_page=10", ""},
+	}
+	for _, tt := range tests {
+		var articles []article
+		paging := Paginate(newCursorContext(url.Values{"page": {tt.page}}), db.Model(&article{}), &articles, "/articles", 10)
+		if paging.PrevPageURL != tt.wantPrev || paging.NextPageURL != tt.wantNext {
+			t.Errorf("page %s: prev = %q, next = %q, want %q, %q", tt.page, paging.PrevPageURL, paging.NextPageURL, tt.wantPrev, tt.wantNext)
+		}
+	}
+}
+
+func TestPaginateEmpty(t *testing.T) {
+	db := openArticles(t, 0)
+
+	var articles []article
+	paging := Paginate(newCursorContext(url.Values{"page": {"2"}}), db.Model(&article{}), &articles, "/articles", 10)
+	if paging.CurrentPage != 0 || paging.TotalPage != 0 || paging.NextPageURL != "" || paging.PrevPageURL != "" || len(articles) != 0 {
+		t.Errorf("empty table paging = %+v, rows = %d, want page 0 without links", paging, len(articles))
+	}
+}
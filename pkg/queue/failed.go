@@ -0,0 +1,102 @@
+package queue
+
+import (
+	"encoding/json"
+	"errors"
+	"gohub/pkg/app"
+	"gohub/pkg/redis"
+	"time"
+)
+
+// ErrFailedJobNotFound 失败列表中没有对应 ID 的任务
+var ErrFailedJobNotFound = errors.New("失败任务不存在")
+
+// FailedJob 失败任务的信息, 用以 queue:failed 命令展示
+type FailedJob struct {
+	ID       string
+	Name     string
+	Queue    string
+	Attempts int
+	Error    string
+	FailedAt time.Time
+}
+
+// Failed 返回失败列表中的所有任务, 最近失败的在前
+func Failed() ([]FailedJob, error) {
+	raws, err := redis.Redis.Client.LRange(redis.Redis.Context, failedKey(), 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	failedJobs := make([]FailedJob, 0, len(raws))
+	for _, raw := range raws {
+		var p payload
+		if err := json.Unmarshal([]byte(raw), &p); err != nil {
+			continue
+		}
+		failedJobs = append(failedJobs, FailedJob{
+			ID:       p.ID,
+			Name:     p.Name,
+			Queue:    p.Queue,
+			Attempts: p.Attempts,
+			Error:    p.LastError,
+			FailedAt: time.Unix(p.FailedAt, 0).In(app.TimenowInTimezone().Location()),
+		})
+	}
+	return failedJobs, nil
+}
+
+// Retry 将 ID 为 id 的失败任务放回原来的队列, 尝试次数重新计算
+func Retry(id string) error {
+	count, err := retryWhere(func(p payload) bool {
+		return p.ID == id
+	})
+	if err == nil && count == 0 {
+		return ErrFailedJobNotFound
+	}
+	return err
+}
+
+// RetryAll 将所有失败任务放回原来的队列, 返回放回的任务数量
+func RetryAll() (int, error) {
+	return retryWhere(func(p payload) bool {
+		return true
+	})
+}
+
+// retryWhere 将满足 match 的失败任务放回原来的队列
+func retryWhere(match func(p payload) bool) (count int, err error) {
+	raws, err := redis.Redis.Client.LRange(redis.Redis.Context, failedKey(), 0, -1).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	for _, raw := range raws {
+		var p payload
+		if json.Unmarshal([]byte(raw), &p) != nil || !match(p) {
+			continue
+		}
+
+		// 先从失败列表移除, 移除成功才放回队列, 避免并发执行时重复放回
+		removed, err := redis.Redis.Client.LRem(redis.Redis.Context, failedKey(), 1, raw).Result()
+		if err != nil {
+			return count, err
+		}
+		if removed == 0 {
+			continue
+		}
+
+		p.Attempts = 0
+		p.LastError = ""
+		p.FailedAt = 0
+		encoded, err := json.Marshal(p)
+		if err != nil {
+			return count, err
+		}
+		if err := redis.Redis.Client.LPush(redis.Redis.Context, readyKey(p.Queue), encoded).Err(); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
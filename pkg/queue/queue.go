@@ -0,0 +1,146 @@
+// Package queue 基于 Redis 的异步任务队列, 用以处理发送邮件、短信等耗时的工作
+package queue
+
+import (
+	"encoding/json"
+	"errors"
+	"gohub/pkg/app"
+	"gohub/pkg/config"
+	"gohub/pkg/helpers"
+	"gohub/pkg/logger"
+	"gohub/pkg/redis"
+	"reflect"
+	"sync"
+)
+
+// Job 队列任务, 任务的字段会以 JSON 格式存储到 Redis 中, 需为可导出字段
+// 用法:
+//
+//	type SendWelcomeJob struct {
+//		UserID uint64
+//	}
+//
+//	func (job *SendWelcomeJob) Name() string { return "send_welcome" }
+//	func (job *SendWelcomeJob) Handle() error { ... }
+//
+//	func init() {
+//		queue.Register(&SendWelcomeJob{})
+//	}
+//
+//	queue.Dispatch(&SendWelcomeJob{UserID: 1})
+type Job interface {
+	// Name 任务名称, 全局唯一, Worker 据此还原出任务对象
+	Name() string
+
+	// Handle 执行任务, 返回错误时按 queue.max_tries 和 queue.backoff 重试
+	Handle() error
+}
+
+// ErrJobNotRegistered 任务未注册, 无法还原出任务对象
+var ErrJobNotRegistered = errors.New("队列任务未注册")
+
+// payload 存储在 Redis 中的任务数据
+type payload struct {
+	ID        string          `json:"id"`
+	Name      string          `json:"name"`
+	Queue     string          `json:"queue"`
+	Data      json.RawMessage `json:"data"`
+	Attempts  int             `json:"attempts"`
+	CreatedAt int64           `json:"created_at"`
+
+	// 失败信息, 最后一次执行的错误, 以及进入失败列表的时间
+	LastError string `json:"last_error,omitempty"`
+	FailedAt  int64  `json:"failed_at,omitempty"`
+}
+
+// jobs 已注册的任务类型, key 为任务名称
+var jobs = map[string]reflect.Type{}
+var jobsMutex sync.RWMutex
+
+// Register 注册任务类型, 一般在任务所在包的 init 函数中调用
+func Register(job Job) {
+	jobsMutex.Lock()
+	defer jobsMutex.Unlock()
+	jobs[job.Name()] = reflect.TypeOf(job).Elem()
+}
+
+// Dispatch 将任务推送到默认队列 queue.default
+func Dispatch(job Job) error {
+	return DispatchOn(config.GetString("queue.default"), job)
+}
+
+// DispatchOn 将任务推送到指定队列, 推送成功即返回 nil, 任务的执行结果只记录在日志和失败列表中
+// queue.connection 为 sync 时不经过 Redis, 直接执行任务并返回任务的错误, 适用于本地开发
+func DispatchOn(queueName string, job Job) error {
+
+	if config.GetString("queue.connection") == "sync" {
+		return job.Handle()
+	}
+
+	data, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+
+	p := payload{
+		ID:        helpers.RandomString(20),
+		Name:      job.Name(),
+		Queue:     queueName,
+		Data:      data,
+		CreatedAt: app.TimenowInTimezone().Unix(),
+	}
+	encoded, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	if err := redis.Redis.Client.LPush(redis.Redis.Context, readyKey(queueName), encoded).Err(); err != nil {
+		logger.ErrorString("队列", "推送任务 "+p.Name+" 出错", err.Error())
+		return err
+	}
+
+	logger.DebugJSON("队列", "推送任务", p)
+	return nil
+}
+
+// newJob 根据 payload 还原出任务对象
+func newJob(p payload) (Job, error) {
+	jobsMutex.RLock()
+	jobType, ok := jobs[p.Name]
+	jobsMutex.RUnlock()
+	if !ok {
+		return nil, ErrJobNotRegistered
+	}
+
+	job := reflect.New(jobType).Interface().(Job)
+	if err := json.Unmarshal(p.Data, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// keyPrefix 队列在 Redis 中的 key 前缀
+func keyPrefix() string {
+	return config.GetString("app.name") + ":queue:"
+}
+
+// readyKey 待执行任务的列表
+func readyKey(queueName string) string {
+	return keyPrefix() + queueName
+}
+
+// reservedKey 正在执行的任务, 有序集合, score 为执行超时的时间戳
+// Worker 异常退出时, 任务会留在这里, 超时后被放回待执行列表
+func reservedKey(queueName string) string {
+	return keyPrefix() + queueName + ":reserved"
+}
+
+// delayedKey 等待重试的任务, 有序集合, score 为可以执行的时间戳
+func delayedKey(queueName string) string {
+	return keyPrefix() + queueName + ":delayed"
+}
+
+// failedKey 失败任务列表 (死信队列), 所有队列共用
+func failedKey() string {
+	return keyPrefix() + "failed"
+}
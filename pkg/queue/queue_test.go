@@ -0,0 +1,162 @@
+package queue
+
+import (
+	"errors"
+	"gohub/pkg/config"
+	"gohub/pkg/logger"
+	"gohub/pkg/redis"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"go.uber.org/zap"
+)
+
+// testJob 测试用任务, 返回 Err 中的错误
+type testJob struct {
+	Err string
+}
+
+var handled int
+
+func (job *testJob) Name() string { return "test" }
+
+func (job *testJob) Handle() error {
+	handled++
+	if len(job.Err) > 0 {
+		return errors.New(job.Err)
+	}
+	return nil
+}
+
+func TestMain(m *testing.M) {
+	logger.Logger = zap.NewNop()
+
+	mr, err := miniredis.Run()
+	if err != nil {
+		panic(err)
+	}
+	defer mr.Close()
+	redis.Redis = redis.NewClient(mr.Addr(), "", "", 0)
+
+	config.Set("app.name", "test")
+	config.Set("queue.connection", "redis")
+	config.Set("queue.default", "default")
+	config.Set("queue.max_tries", 3)
+	config.Set("queue.retry_after", 90)
+	Register(&testJob{})
+
+	os.Exit(m.Run())
+}
+
+func TestBackoff(t *testing.T) {
+	config.Set("queue.backoff", 10)
+
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{0, 10 * time.Second},
+		{1, 10 * time.Second},
+		{2, 20 * time.Second},
+		{3, 40 * time.Second},
+	}
+	for _, tt := range tests {
+		if got := backoff(tt.attempts); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestRetryUntilFailed(t *testing.T) {
+	redis.Redis.FlushDB()
+	handled = 0
+	// 不等待, 失败的任务立即可以重试
+	config.Set("queue.backoff", 0)
+
+	if err := Dispatch(&testJob{Err: "boom"}); err != nil {
+		t.Fatalf("Dispatch() error = %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		migrateExpired(delayedKey("default"), readyKey("default"))
+		queueName, raw, err := reserve([]string{"default"})
+		if err != nil {
+			t.Fatalf("reserve() #%d error = %v", i+1, err)
+		}
+		process(queueName, raw)
+	}
+
+	if handled != 3 {
+		t.Errorf("handled = %d, want 3", handled)
+	}
+	if count := redis.Redis.ZCard(reservedKey("default")); count != 0 {
+		t.Errorf("reserved jobs = %d, want 0", count)
+	}
+
+	failed, err := Failed()
+	if err != nil || len(failed) != 1 {
+		t.Fatalf("Failed() = %v, %v, want 1 job", failed, err)
+	}
+	if failed[0].Attempts != 3 || failed[0].Error != "boom" {
+		t.Errorf("failed job = %+v, want 3 attempts and error boom", failed[0])
+	}
+
+	// 重试后回到队列, 尝试次数重新计算
+	if err := Retry(failed[0].ID); err != nil {
+		t.Fatalf("Retry() error = %v", err)
+	}
+	if _, _, err := reserve([]string{"default"}); err != nil {
+		t.Errorf("reserve() after Retry error = %v", err)
+	}
+	if err := Retry(failed[0].ID); !errors.Is(err, ErrFailedJobNotFound) {
+		t.Errorf("Retry() again error = %v, want ErrFailedJobNotFound", err)
+	}
+}
+
+func TestReserveByPriority(t *testing.T) {
+	redis.Redis.FlushDB()
+
+	DispatchOn("low", &testJob{})
+	DispatchOn("high", &testJob{})
+
+	for _, want := range []string{"high", "low"} {
+		queueName, _, err := reserve([]string{"high", "low"})
+		if err != nil || queueName != want {
+			t.Errorf("reserve() = %q, %v, want %q", queueName, err, want)
+		}
+	}
+	if _, _, err := reserve([]string{"high", "low"}); !errors.Is(err, redis.Nil) {
+		t.Errorf("reserve() on empty queues error = %v, want redis.Nil", err)
+	}
+}
+
+// TestRecoverReservedJob Worker 取出任务后异常退出, 任务超时后回到队列
+func TestRecoverReservedJob(t *testing.T) {
+	redis.Redis.FlushDB()
+	handled = 0
+
+	Dispatch(&testJob{})
+	if _, _, err := reserve([]string{"default"}); err != nil {
+		t.Fatalf("reserve() error = %v", err)
+	}
+
+	// 未超时, 任务仍在执行中
+	migrateExpired(reservedKey("default"), readyKey("default"))
+	if _, _, err := reserve([]string{"default"}); !errors.Is(err, redis.Nil) {
+		t.Fatalf("reserve() before timeout error = %v, want redis.Nil", err)
+	}
+
+	// 超时后放回队列, 可以再次执行
+	redis.Redis.ZAdd(reservedKey("default"), redis.Z{Score: 0, Member: redis.Redis.ZRange(reservedKey("default"), 0, 0)[0]})
+	migrateExpired(reservedKey("default"), readyKey("default"))
+	queueName, raw, err := reserve([]string{"default"})
+	if err != nil {
+		t.Fatalf("reserve() after timeout error = %v", err)
+	}
+	process(queueName, raw)
+	if handled != 1 || redis.Redis.ZCard(reservedKey("default")) != 0 {
+		t.Errorf("handled = %d, reserved = %d, want 1 and 0", handled, redis.Redis.ZCard(reservedKey("default")))
+	}
+}
@@ -0,0 +1,264 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gohub/pkg/config"
+	"gohub/pkg/logger"
+	"gohub/pkg/redis"
+	"time"
+
+	"github.com/spf13/cast"
+)
+
+// ErrMaxAttemptsExceeded 任务执行超时或 Worker 异常退出的次数过多, 不再执行
+var ErrMaxAttemptsExceeded = errors.New("任务执行超时或 Worker 异常退出的次数已达上限")
+
+// maxTrier 任务可以实现 MaxTries 方法, 覆盖 queue.max_tries 配置
+type maxTrier interface {
+	MaxTries() int
+}
+
+// migrateExpiredScript 将有序集合中已到时间的任务移回待执行列表, 使用脚本保证原子性
+// 用于到时间的重试任务 (delayed), 以及执行超时的任务 (reserved)
+var migrateExpiredScript = redis.NewScript(`
+local jobs = redis.call('zrangebyscore', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, 100)
+if #jobs > 0 then
+	redis.call('zrem', KEYS[1], unpack(jobs))
+	for _, job in ipairs(jobs) do
+		redis.call('lpush', KEYS[2], job)
+	end
+end
+return #jobs
+`)
+
+// reserveScript 按顺序从第一个有任务的队列中取出一个任务, 同时放入该队列的 reserved 集合
+// KEYS 依次为每个队列的 ready 和 reserved, ARGV[1] 为执行超时的时间戳
+// 取出和放入在同一脚本中完成, Worker 在任何时刻退出都不会丢失任务
+var reserveScript = redis.NewScript(`
+for i = 1, #KEYS, 2 do
+	local job = redis.call('rpop', KEYS[i])
+	if job then
+		redis.call('zadd', KEYS[i + 1], ARGV[1], job)
+		return {(i + 1) / 2, job}
+	end
+end
+return false
+`)
+
+// Work 从 queues 中按顺序取出任务并执行, 排在前面的队列优先
+// 阻塞运行, 直到 ctx 被取消, 正在执行的任务会先执行完毕再退出
+func Work(ctx context.Context, queues []string) {
+
+	sleep := time.Duration(config.GetInt64("queue.sleep")) * time.Second
+
+	for ctx.Err() == nil {
+
+		// 1. 到时间的重试任务, 以及执行超时的任务放回队列
+		for _, queueName := range queues {
+			migrateExpired(delayedKey(queueName), readyKey(queueName))
+			migrateExpired(reservedKey(queueName), readyKey(queueName))
+		}
+
+		// 2. 取出任务, 没有任务时等待 queue.sleep 后再检查
+		// 不使用 ctx 作为参数, 避免任务已被取出时连接被中断
+		queueName, raw, err := reserve(queues)
+		if err != nil {
+			if !errors.Is(err, redis.Nil) {
+				logger.ErrorString("队列", "读取任务出错", err.Error())
+			}
+			select {
+			case <-ctx.Done():
+			case <-time.After(sleep):
+			}
+			continue
+		}
+
+		// 3. 执行任务
+		process(queueName, raw)
+	}
+}
+
+// reserve 取出一个任务并标记为执行中, 所有队列都没有任务时返回 redis.Nil
+func reserve(queues []string) (queueName string, raw string, err error) {
+	keys := make([]string, 0, len(queues)*2)
+	for _, name := range queues {
+		keys = append(keys, readyKey(name), reservedKey(name))
+	}
+
+	result, err := redis.Redis.RunContext(redis.Redis.Context, reserveScript, keys, reservedUntil())
+	if err != nil {
+		return "", "", err
+	}
+
+	// 脚本返回 [队列序号 (从 1 开始), 任务]
+	values := result.([]interface{})
+	return queues[cast.ToInt(values[0])-1], cast.ToString(values[1]), nil
+}
+
+// migrateExpired 将有序集合 from 中已到时间的任务放回待执行列表 to
+func migrateExpired(from string, to string) {
+	_, err := redis.Redis.RunContext(redis.Redis.Context, migrateExpiredScript, []string{from, to}, time.Now().Unix())
+	if err != nil && !errors.Is(err, redis.Nil) {
+		logger.ErrorString("队列", "移动到期任务出错", err.Error())
+	}
+}
+
+// reservedUntil 执行超时的时间戳, 任务执行超过 queue.retry_after 秒后, 会被放回队列再次执行
+func reservedUntil() int64 {
+	return time.Now().Add(time.Duration(config.GetInt64("queue.retry_after")) * time.Second).Unix()
+}
+
+// process 执行单个任务, 失败时安排重试, 超过最大尝试次数后放入失败列表
+// raw 为 reserved 集合中的任务数据, 任务结束时从集合中移除
+func process(queueName string, raw string) {
+
+	var p payload
+	if err := json.Unmarshal([]byte(raw), &p); err != nil {
+		logger.ErrorString("队列", "解析任务出错", err.Error()+": "+raw)
+		redis.Redis.ZRem(reservedKey(queueName), raw)
+		return
+	}
+	p.Queue = queueName
+
+	job, err := newJob(p)
+	if err != nil {
+		// 任务无法还原, 重试也不会成功
+		p.Attempts++
+		fail(p, raw, err)
+		return
+	}
+
+	// 执行前记录尝试次数, Worker 执行任务时异常退出, 也会计入尝试次数
+	p.Attempts++
+	raw, err = touch(p, raw)
+	if err != nil {
+		logger.ErrorString("队列", "更新任务出错", err.Error())
+		return
+	}
+
+	maxTries := config.GetInt("queue.max_tries")
+	if t, ok := job.(maxTrier); ok {
+		maxTries = t.MaxTries()
+	}
+	if p.Attempts > maxTries {
+		fail(p, raw, ErrMaxAttemptsExceeded)
+		return
+	}
+
+	start := time.Now()
+	err = handle(job)
+	if err == nil {
+		redis.Redis.ZRem(reservedKey(p.Queue), raw)
+		logger.InfoJSON("队列", "任务完成", map[string]interface{}{
+			"id":       p.ID,
+			"name":     p.Name,
+			"queue":    p.Queue,
+			"attempts": p.Attempts,
+			"duration": time.Since(start).String(),
+		})
+		return
+	}
+
+	if p.Attempts >= maxTries {
+		fail(p, raw, err)
+		return
+	}
+
+	delay := backoff(p.Attempts)
+	logger.WarnJSON("队列", "任务失败, 等待重试", map[string]interface{}{
+		"id":       p.ID,
+		"name":     p.Name,
+		"attempts": p.Attempts,
+		"delay":    delay.String(),
+		"error":    err.Error(),
+	})
+	p.LastError = err.Error()
+	release(p, raw, delay)
+}
+
+// backoff 第 attempts 次执行失败后, 等待重试的时间
+// 指数退避: backoff, backoff*2, backoff*4 ...
+func backoff(attempts int) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+	return time.Duration(config.GetInt64("queue.backoff")) * time.Second << (attempts - 1)
+}
+
+// handle 执行任务, 任务 panic 时视为执行失败, 避免 Worker 退出
+func handle(job Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return job.Handle()
+}
+
+// touch 使用最新的任务数据替换 reserved 集合中的 raw, 返回新的任务数据
+func touch(p payload, raw string) (string, error) {
+	encoded, err := json.Marshal(p)
+	if err != nil {
+		return raw, err
+	}
+	err = redis.Redis.TxPipelinedContext(redis.Redis.Context, func(pipe redis.Pipeliner) error {
+		pipe.ZRem(redis.Redis.Context, reservedKey(p.Queue), raw)
+		pipe.ZAdd(redis.Redis.Context, reservedKey(p.Queue), &redis.Z{
+			Score:  cast.ToFloat64(reservedUntil()),
+			Member: string(encoded),
+		})
+		return nil
+	})
+	return string(encoded), err
+}
+
+// release 将任务从 reserved 集合移到重试集合, delay 后可被再次执行
+func release(p payload, raw string, delay time.Duration) {
+	encoded, err := json.Marshal(p)
+	if err != nil {
+		logger.LogIf(err)
+		return
+	}
+	err = redis.Redis.TxPipelinedContext(redis.Redis.Context, func(pipe redis.Pipeliner) error {
+		pipe.ZRem(redis.Redis.Context, reservedKey(p.Queue), raw)
+		pipe.ZAdd(redis.Redis.Context, delayedKey(p.Queue), &redis.Z{
+			Score:  cast.ToFloat64(time.Now().Add(delay).Unix()),
+			Member: string(encoded),
+		})
+		return nil
+	})
+	if err != nil {
+		logger.ErrorString("队列", "安排重试出错", err.Error())
+	}
+}
+
+// fail 将任务从 reserved 集合移到失败列表, 可使用 queue:retry 命令重新执行
+func fail(p payload, raw string, err error) {
+	p.LastError = err.Error()
+	p.FailedAt = time.Now().Unix()
+
+	logger.ErrorJSON("队列", "任务失败", map[string]interface{}{
+		"id":       p.ID,
+		"name":     p.Name,
+		"queue":    p.Queue,
+		"attempts": p.Attempts,
+		"error":    p.LastError,
+	})
+
+	encoded, marshalErr := json.Marshal(p)
+	if marshalErr != nil {
+		logger.LogIf(marshalErr)
+		return
+	}
+	pushErr := redis.Redis.TxPipelinedContext(redis.Redis.Context, func(pipe redis.Pipeliner) error {
+		pipe.ZRem(redis.Redis.Context, reservedKey(p.Queue), raw)
+		pipe.LPush(redis.Redis.Context, failedKey(), encoded)
+		return nil
+	})
+	if pushErr != nil {
+		logger.ErrorString("队列", "保存失败任务出错", pushErr.Error())
+	}
+}
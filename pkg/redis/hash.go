@@ -0,0 +1,75 @@
+package redis
+
+import "context"
+
+// HSetContext 设置哈希表 key 中的字段, values 支持以下写法:
+//
+//	HSetContext(ctx, "user:1", "name", "summer", "age", 18)
+//	HSetContext(ctx, "user:1", map[string]interface{}{"name": "summer", "age": 18})
+func (rds RedisClient) HSetContext(ctx context.Context, key string, values ...interface{}) error {
+	return rds.Client.HSet(ctx, key, values...).Err()
+}
+
+// HSet 设置哈希表 key 中的字段, 参数同 HSetContext
+func (rds RedisClient) HSet(key string, values ...interface{}) bool {
+	return rds.logIf("HSet", rds.HSetContext(rds.Context, key, values...))
+}
+
+// HGetContext 获取哈希表 key 中字段 field 的值, 字段不存在时返回 redis.Nil 错误
+func (rds RedisClient) HGetContext(ctx context.Context, key string, field string) (string, error) {
+	return rds.Client.HGet(ctx, key, field).Result()
+}
+
+// HGet 获取哈希表 key 中字段 field 的值
+func (rds RedisClient) HGet(key string, field string) string {
+	result, err := rds.HGetContext(rds.Context, key, field)
+	rds.logIf("HGet", err)
+	return result
+}
+
+// HGetAllContext 获取哈希表 key 中的所有字段和值
+func (rds RedisClient) HGetAllContext(ctx context.Context, key string) (map[string]string, error) {
+	return rds.Client.HGetAll(ctx, key).Result()
+}
+
+// HGetAll 获取哈希表 key 中的所有字段和值, 出错时返回空 map
+func (rds RedisClient) HGetAll(key string) map[string]string {
+	result, err := rds.HGetAllContext(rds.Context, key)
+	if !rds.logIf("HGetAll", err) {
+		return map[string]string{}
+	}
+	return result
+}
+
+// HExistsContext 判断哈希表 key 中是否存在字段 field
+func (rds RedisClient) HExistsContext(ctx context.Context, key string, field string) (bool, error) {
+	return rds.Client.HExists(ctx, key, field).Result()
+}
+
+// HExists 判断哈希表 key 中是否存在字段 field
+func (rds RedisClient) HExists(key string, field string) bool {
+	ok, err := rds.HExistsContext(rds.Context, key, field)
+	return rds.logIf("HExists", err) && ok
+}
+
+// HDelContext 删除哈希表 key 中的字段, 返回删除的数量
+func (rds RedisClient) HDelContext(ctx context.Context, key string, fields ...string) (int64, error) {
+	return rds.Client.HDel(ctx, key, fields...).Result()
+}
+
+// HDel 删除哈希表 key 中的字段
+func (rds RedisClient) HDel(key string, fields ...string) bool {
+	_, err := rds.HDelContext(rds.Context, key, fields...)
+	return rds.logIf("HDel", err)
+}
+
+// HIncrementContext 将哈希表 key 中字段 field 的值增加 value, 返回增加后的值
+func (rds RedisClient) HIncrementContext(ctx context.Context, key string, field string, value int64) (int64, error) {
+	return rds.Client.HIncrBy(ctx, key, field, value).Result()
+}
+
+// HIncrement 将哈希表 key 中字段 field 的值增加 value
+func (rds RedisClient) HIncrement(key string, field string, value int64) bool {
+	_, err := rds.HIncrementContext(rds.Context, key, field, value)
+	return rds.logIf("HIncrement", err)
+}
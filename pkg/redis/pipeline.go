@@ -0,0 +1,38 @@
+package redis
+
+import (
+	"context"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Pipeliner 管道, 调用方无需再引入 go-redis
+type Pipeliner = redis.Pipeliner
+
+// PipelinedContext 使用管道一次性发送多条命令, 减少网络往返, 用法:
+//
+//	err := redis.Redis.PipelinedContext(ctx, func(pipe redis.Pipeliner) error {
+//	    pipe.Incr(ctx, "counter")
+//	    pipe.Expire(ctx, "counter", time.Hour)
+//	    return nil
+//	})
+func (rds RedisClient) PipelinedContext(ctx context.Context, fn func(Pipeliner) error) error {
+	_, err := rds.Client.Pipelined(ctx, fn)
+	return err
+}
+
+// Pipelined 使用管道一次性发送多条命令, 出错时记录日志并返回 false
+func (rds RedisClient) Pipelined(fn func(Pipeliner) error) bool {
+	return rds.logIf("Pipelined", rds.PipelinedContext(rds.Context, fn))
+}
+
+// TxPipelinedContext 同 PipelinedContext, 命令包裹在 MULTI/EXEC 中以事务方式执行
+func (rds RedisClient) TxPipelinedContext(ctx context.Context, fn func(Pipeliner) error) error {
+	_, err := rds.Client.TxPipelined(ctx, fn)
+	return err
+}
+
+// TxPipelined 以事务方式执行管道命令, 出错时记录日志并返回 false
+func (rds RedisClient) TxPipelined(fn func(Pipeliner) error) bool {
+	return rds.logIf("TxPipelined", rds.TxPipelinedContext(rds.Context, fn))
+}
@@ -1,14 +1,23 @@
+// Package redis 封装 go-redis, 提供全局的 Redis 对象
+// 每个操作都有两种写法:
+//   - XxxContext 接收 context, 返回真实的错误, 调用方可以取消、设置超时和处理错误
+//   - Xxx 使用 RedisClient.Context, 出错时记录日志并返回 bool 或零值, 适合不关心错误细节的场景
 package redis
 
 import (
 	"context"
+	"errors"
 	"gohub/pkg/logger"
 	"sync"
 	"time"
 
 	"github.com/go-redis/redis/v8"
+	"github.com/spf13/cast"
 )
 
+// Nil key 不存在时 GetContext 等方法返回的错误, 可用 errors.Is(err, redis.Nil) 判断
+const Nil = redis.Nil
+
 // RedisClient Redis 服务
 type RedisClient struct {
 	Client  *redis.Client
@@ -51,105 +60,155 @@ func NewClient(address string, username string, password string, db int) *RedisC
 
 // Ping 用以测试 redis 连接是否正常
 func (rds RedisClient) Ping() error {
-	_, err := rds.Client.Ping(rds.Context).Result()
-	return err
+	return rds.PingContext(rds.Context)
+}
+
+// PingContext 用以测试 redis 连接是否正常
+func (rds RedisClient) PingContext(ctx context.Context) error {
+	return rds.Client.Ping(ctx).Err()
+}
+
+// SetContext 存储 key 对应的 value 且设置 expiration 过期时间, expiration 为 0 时永不过期
+func (rds RedisClient) SetContext(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	return rds.Client.Set(ctx, key, value, expiration).Err()
 }
 
 // Set 存储 key 对应的 value 且设置 expiration 过期时间
 func (rds RedisClient) Set(key string, value interface{}, expiration time.Duration) bool {
-	if err := rds.Client.Set(rds.Context, key, value, expiration).Err(); err != nil {
-		logger.ErrorString("Redis", "Set", err.Error())
-		return false
-	}
-	return true
+	return rds.logIf("Set", rds.SetContext(rds.Context, key, value, expiration))
+}
+
+// SetNXContext key 不存在时才存储, 返回是否存储成功, 可用作分布式锁
+func (rds RedisClient) SetNXContext(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	return rds.Client.SetNX(ctx, key, value, expiration).Result()
+}
+
+// SetNX key 不存在时才存储, key 已存在或出错时返回 false
+func (rds RedisClient) SetNX(key string, value interface{}, expiration time.Duration) bool {
+	ok, err := rds.SetNXContext(rds.Context, key, value, expiration)
+	return rds.logIf("SetNX", err) && ok
+}
+
+// GetContext 获取 key 对应的 value, key 不存在时返回 redis.Nil 错误
+func (rds RedisClient) GetContext(ctx context.Context, key string) (string, error) {
+	return rds.Client.Get(ctx, key).Result()
 }
 
 // Get 获取 key 对应的 value
 func (rds RedisClient) Get(key string) string {
-	result, err := rds.Client.Get(rds.Context, key).Result()
-	if err != nil {
-		if err != redis.Nil {
-			logger.ErrorString("Redis", "Get", err.Error())
-		}
-		return ""
-	}
+	result, err := rds.GetContext(rds.Context, key)
+	rds.logIf("Get", err)
 	return result
 }
 
-// Has 判断一个 key 是否存在, 内部错误和 redis.Nil 都返回 false
+// HasContext 判断一个 key 是否存在, 支持所有数据类型
+func (rds RedisClient) HasContext(ctx context.Context, key string) (bool, error) {
+	count, err := rds.Client.Exists(ctx, key).Result()
+	return count > 0, err
+}
+
+// Has 判断一个 key 是否存在, 内部错误也返回 false
 func (rds RedisClient) Has(key string) bool {
-	_, err := rds.Client.Get(rds.Context, key).Result()
-	if err != nil {
-		if err != redis.Nil {
-			logger.ErrorString("Redis", "Has", err.Error())
-		}
-		return false
-	}
-	return true
+	ok, err := rds.HasContext(rds.Context, key)
+	return rds.logIf("Has", err) && ok
+}
+
+// DelContext 删除存储在 redis 里的数据, 支持多个 key 传参, 返回删除的数量
+func (rds RedisClient) DelContext(ctx context.Context, keys ...string) (int64, error) {
+	return rds.Client.Del(ctx, keys...).Result()
 }
 
 // Del 删除存储在 redis 里的数据, 支持多个 key 传惨
 func (rds RedisClient) Del(keys ...string) bool {
-	if err := rds.Client.Del(rds.Context, keys...).Err(); err != nil {
-		logger.ErrorString("Redis", "Del", err.Error())
-		return false
-	}
-	return true
+	_, err := rds.DelContext(rds.Context, keys...)
+	return rds.logIf("Del", err)
+}
+
+// FlushDBContext 清空当前 redis db 里的所有数据
+func (rds RedisClient) FlushDBContext(ctx context.Context) error {
+	return rds.Client.FlushDB(ctx).Err()
 }
 
 // FlushDB 清空当前 redis db 里的所有数据
+// 注意同一 db 中其他程序的数据也会被清除, 清除缓存请使用 cache.Flush
 func (rds RedisClient) FlushDB() bool {
-	if err := rds.Client.FlushDB(rds.Context).Err(); err != nil {
-		logger.ErrorString("Redis", "FlushDB", err.Error())
-		return false
-	}
-	return true
+	return rds.logIf("FlushDB", rds.FlushDBContext(rds.Context))
+}
+
+// ExpireContext 设置 key 的过期时间, key 不存在时返回 false
+func (rds RedisClient) ExpireContext(ctx context.Context, key string, expiration time.Duration) (bool, error) {
+	return rds.Client.Expire(ctx, key, expiration).Result()
+}
+
+// Expire 设置 key 的过期时间, key 不存在或出错时返回 false
+func (rds RedisClient) Expire(key string, expiration time.Duration) bool {
+	ok, err := rds.ExpireContext(rds.Context, key, expiration)
+	return rds.logIf("Expire", err) && ok
+}
+
+// TTLContext 获取 key 的剩余过期时间
+// 永不过期时返回 -1, key 不存在时返回 -2 (与 go-redis 一致, 单位为纳秒)
+func (rds RedisClient) TTLContext(ctx context.Context, key string) (time.Duration, error) {
+	return rds.Client.TTL(ctx, key).Result()
+}
+
+// TTL 获取 key 的剩余过期时间, 返回值同 TTLContext, 出错时返回 0
+func (rds RedisClient) TTL(key string) time.Duration {
+	ttl, err := rds.TTLContext(rds.Context, key)
+	rds.logIf("TTL", err)
+	return ttl
+}
+
+// IncrementContext 将 key 的值增加 value, 返回增加后的值
+func (rds RedisClient) IncrementContext(ctx context.Context, key string, value int64) (int64, error) {
+	return rds.Client.IncrBy(ctx, key, value).Result()
 }
 
 // Increment 当参数只有 1 个时, 为 key, 其值增加 1
-// 当参数有 2 个时, 第一个参数为 key , 第二个参数为要增加的值 int64 类型
+// 当参数有 2 个时, 第一个参数为 key , 第二个参数为要增加的值, 支持 int 和 int64 等整数类型
 func (rds RedisClient) Increment(parameters ...interface{}) bool {
-	switch len(parameters) {
-	case 1:
-		key := parameters[0].(string)
-		if err := rds.Client.Incr(rds.Context, key).Err(); err != nil {
-			logger.ErrorString("Redis", "Increment", err.Error())
-			return false
-		}
-	case 2:
-		key := parameters[0].(string)
-		value := parameters[0].(int64)
-		if err := rds.Client.IncrBy(rds.Context, key, value).Err(); err != nil {
-			logger.ErrorString("Redis", "Increment", err.Error())
-			return false
-		}
-	default:
-		logger.ErrorString("Redis", "Increment", "参数过多")
-		return false
+	key, value, err := incrementParameters(parameters)
+	if err == nil {
+		_, err = rds.IncrementContext(rds.Context, key, value)
 	}
-	return true
+	return rds.logIf("Increment", err)
+}
+
+// DecrementContext 将 key 的值减去 value, 返回减去后的值
+func (rds RedisClient) DecrementContext(ctx context.Context, key string, value int64) (int64, error) {
+	return rds.Client.DecrBy(ctx, key, value).Result()
 }
 
 // Decrement 当参数只有 1 个时, 为 key, 其值减去 1
-// 当参数有 2 个时,第一个参数为 key, 第二个参数为要减去的值 int64 类型
+// 当参数有 2 个时,第一个参数为 key, 第二个参数为要减去的值, 支持 int 和 int64 等整数类型
 func (rds RedisClient) Decrement(parameters ...interface{}) bool {
+	key, value, err := incrementParameters(parameters)
+	if err == nil {
+		_, err = rds.DecrementContext(rds.Context, key, value)
+	}
+	return rds.logIf("Decrement", err)
+}
+
+// incrementParameters 解析 Increment 和 Decrement 的参数, 未传第二个参数时步长为 1
+func incrementParameters(parameters []interface{}) (key string, value int64, err error) {
 	switch len(parameters) {
 	case 1:
-		key := parameters[0].(string)
-		if err := rds.Client.Decr(rds.Context, key).Err(); err != nil {
-			logger.ErrorString("Redis", "Decrement", err.Error())
-			return false
-		}
+		return cast.ToString(parameters[0]), 1, nil
 	case 2:
-		key := parameters[0].(string)
-		value := parameters[1].(int64)
-		if err := rds.Client.DecrBy(rds.Context, key, value).Err(); err != nil {
-			logger.ErrorString("Redis", "Decrement", err.Error())
-			return false
-		}
+		value, err = cast.ToInt64E(parameters[1])
+		return cast.ToString(parameters[0]), value, err
 	default:
-		logger.ErrorString("Redis", "Decrement", "参数过多")
-		return false
+		return "", 0, errors.New("参数数量错误, 只支持 key 或 key 和 value 两种传参")
+	}
+}
+
+// logIf 供 bool 写法使用, 有错误时记录日志并返回 false, key 不存在 (redis.Nil) 不视为需要记录的错误
+func (rds RedisClient) logIf(method string, err error) bool {
+	if err == nil {
+		return true
+	}
+	if !errors.Is(err, redis.Nil) {
+		logger.ErrorString("Redis", method, err.Error())
 	}
-	return true
+	return false
 }
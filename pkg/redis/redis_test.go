@@ -0,0 +1,59 @@
+package redis
+
+import (
+	"gohub/pkg/logger"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"go.uber.org/zap"
+)
+
+func TestIncrementParameters(t *testing.T) {
+	tests := []struct {
+		name       string
+		parameters []interface{}
+		wantKey    string
+		wantValue  int64
+		wantErr    bool
+	}{
+		{"key only", []interface{}{"visits"}, "visits", 1, false},
+		{"int value", []interface{}{"visits", 5}, "visits", 5, false},
+		{"int64 value", []interface{}{"visits", int64(1) << 40}, "visits", 1 << 40, false},
+		{"negative value", []interface{}{"visits", -3}, "visits", -3, false},
+		{"numeric string value", []interface{}{"visits", "7"}, "visits", 7, false},
+		{"invalid value", []interface{}{"visits", "seven"}, "visits", 0, true},
+		{"no parameters", []interface{}{}, "", 0, true},
+		{"too many parameters", []interface{}{"visits", 1, 2}, "", 0, true},
+	}
+	for _, tt := range tests {
+		key, value, err := incrementParameters(tt.parameters)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: incrementParameters(%v) error = %v, wantErr %v", tt.name, tt.parameters, err, tt.wantErr)
+			continue
+		}
+		if err == nil && (key != tt.wantKey || value != tt.wantValue) {
+			t.Errorf("%s: incrementParameters(%v) = %q, %d, want %q, %d", tt.name, tt.parameters, key, value, tt.wantKey, tt.wantValue)
+		}
+	}
+}
+
+func TestIncrementAndDecrement(t *testing.T) {
+	logger.Logger = zap.NewNop()
+	mr := miniredis.RunT(t)
+	rds := NewClient(mr.Addr(), "", "", 0)
+
+	if !rds.Increment("visits") || !rds.Increment("visits", 10) || !rds.Decrement("visits", int64(4)) || !rds.Decrement("visits") {
+		t.Fatal("Increment/Decrement returned false")
+	}
+	if got := rds.Get("visits"); got != "6" {
+		t.Errorf("visits = %q, want %q", got, "6")
+	}
+
+	// 参数错误时不执行命令, 返回 false
+	if rds.Increment("visits", "ten") || rds.Decrement() {
+		t.Error("Increment/Decrement with invalid parameters returned true")
+	}
+	if got := rds.Get("visits"); got != "6" {
+		t.Errorf("visits after invalid calls = %q, want %q", got, "6")
+	}
+}
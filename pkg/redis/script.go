@@ -0,0 +1,41 @@
+package redis
+
+import (
+	"context"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Script Lua 脚本, 建议定义为包级变量, 以便复用脚本的 SHA1
+type Script = redis.Script
+
+// NewScript 创建 Lua 脚本, 用法:
+//
+//	var incrIfExists = redis.NewScript(`
+//	    if redis.call("EXISTS", KEYS[1]) == 1 then
+//	        return redis.call("INCRBY", KEYS[1], ARGV[1])
+//	    end
+//	    return false
+//	`)
+//	result, err := redis.Redis.RunContext(ctx, incrIfExists, []string{"counter"}, 1)
+func NewScript(src string) *Script {
+	return redis.NewScript(src)
+}
+
+// RunContext 执行 Lua 脚本, 优先使用 EVALSHA, 脚本未缓存时自动改用 EVAL
+// 脚本返回 false (nil) 时, 返回 redis.Nil 错误
+func (rds RedisClient) RunContext(ctx context.Context, script *Script, keys []string, args ...interface{}) (interface{}, error) {
+	return script.Run(ctx, rds.Client, keys, args...).Result()
+}
+
+// Run 执行 Lua 脚本, 出错时记录日志并返回 nil
+func (rds RedisClient) Run(script *Script, keys []string, args ...interface{}) interface{} {
+	result, err := rds.RunContext(rds.Context, script, keys, args...)
+	rds.logIf("Run", err)
+	return result
+}
+
+// EvalContext 直接执行 Lua 脚本源码, 脚本需要多次执行时, 请使用 NewScript 和 RunContext
+func (rds RedisClient) EvalContext(ctx context.Context, src string, keys []string, args ...interface{}) (interface{}, error) {
+	return rds.Client.Eval(ctx, src, keys, args...).Result()
+}
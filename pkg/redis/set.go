@@ -0,0 +1,62 @@
+package redis
+
+import "context"
+
+// SAddContext 向集合 key 添加成员, 返回新添加的数量
+func (rds RedisClient) SAddContext(ctx context.Context, key string, members ...interface{}) (int64, error) {
+	return rds.Client.SAdd(ctx, key, members...).Result()
+}
+
+// SAdd 向集合 key 添加成员
+func (rds RedisClient) SAdd(key string, members ...interface{}) bool {
+	_, err := rds.SAddContext(rds.Context, key, members...)
+	return rds.logIf("SAdd", err)
+}
+
+// SRemContext 从集合 key 移除成员, 返回移除的数量
+func (rds RedisClient) SRemContext(ctx context.Context, key string, members ...interface{}) (int64, error) {
+	return rds.Client.SRem(ctx, key, members...).Result()
+}
+
+// SRem 从集合 key 移除成员
+func (rds RedisClient) SRem(key string, members ...interface{}) bool {
+	_, err := rds.SRemContext(rds.Context, key, members...)
+	return rds.logIf("SRem", err)
+}
+
+// SMembersContext 获取集合 key 的所有成员
+func (rds RedisClient) SMembersContext(ctx context.Context, key string) ([]string, error) {
+	return rds.Client.SMembers(ctx, key).Result()
+}
+
+// SMembers 获取集合 key 的所有成员, 出错时返回空切片
+func (rds RedisClient) SMembers(key string) []string {
+	members, err := rds.SMembersContext(rds.Context, key)
+	if !rds.logIf("SMembers", err) {
+		return []string{}
+	}
+	return members
+}
+
+// SIsMemberContext 判断 member 是否为集合 key 的成员
+func (rds RedisClient) SIsMemberContext(ctx context.Context, key string, member interface{}) (bool, error) {
+	return rds.Client.SIsMember(ctx, key, member).Result()
+}
+
+// SIsMember 判断 member 是否为集合 key 的成员
+func (rds RedisClient) SIsMember(key string, member interface{}) bool {
+	ok, err := rds.SIsMemberContext(rds.Context, key, member)
+	return rds.logIf("SIsMember", err) && ok
+}
+
+// SCardContext 获取集合 key 的成员数量
+func (rds RedisClient) SCardContext(ctx context.Context, key string) (int64, error) {
+	return rds.Client.SCard(ctx, key).Result()
+}
+
+// SCard 获取集合 key 的成员数量
+func (rds RedisClient) SCard(key string) int64 {
+	count, err := rds.SCardContext(rds.Context, key)
+	rds.logIf("SCard", err)
+	return count
+}
@@ -0,0 +1,116 @@
+package redis
+
+import (
+	"context"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Z 有序集合的成员和分数, 调用方无需再引入 go-redis
+type Z = redis.Z
+
+// ZAddContext 向有序集合 key 添加成员, 成员已存在时更新分数, 返回新添加的数量
+//
+//	redis.Redis.ZAddContext(ctx, "ranking", redis.Z{Score: 100, Member: "user:1"})
+func (rds RedisClient) ZAddContext(ctx context.Context, key string, members ...Z) (int64, error) {
+	zs := make([]*redis.Z, len(members))
+	for i := range members {
+		zs[i] = &members[i]
+	}
+	return rds.Client.ZAdd(ctx, key, zs...).Result()
+}
+
+// ZAdd 向有序集合 key 添加成员
+func (rds RedisClient) ZAdd(key string, members ...Z) bool {
+	_, err := rds.ZAddContext(rds.Context, key, members...)
+	return rds.logIf("ZAdd", err)
+}
+
+// ZIncrementContext 将有序集合 key 中成员 member 的分数增加 increment, 返回增加后的分数
+func (rds RedisClient) ZIncrementContext(ctx context.Context, key string, member string, increment float64) (float64, error) {
+	return rds.Client.ZIncrBy(ctx, key, increment, member).Result()
+}
+
+// ZIncrement 将有序集合 key 中成员 member 的分数增加 increment
+func (rds RedisClient) ZIncrement(key string, member string, increment float64) bool {
+	_, err := rds.ZIncrementContext(rds.Context, key, member, increment)
+	return rds.logIf("ZIncrement", err)
+}
+
+// ZScoreContext 获取有序集合 key 中成员 member 的分数, 成员不存在时返回 redis.Nil 错误
+func (rds RedisClient) ZScoreContext(ctx context.Context, key string, member string) (float64, error) {
+	return rds.Client.ZScore(ctx, key, member).Result()
+}
+
+// ZScore 获取有序集合 key 中成员 member 的分数, 成员不存在或出错时返回 0
+func (rds RedisClient) ZScore(key string, member string) float64 {
+	score, err := rds.ZScoreContext(rds.Context, key, member)
+	rds.logIf("ZScore", err)
+	return score
+}
+
+// ZRangeContext 按分数从低到高, 获取有序集合 key 中下标 start 到 stop 的成员, stop 为 -1 时表示到最后
+func (rds RedisClient) ZRangeContext(ctx context.Context, key string, start int64, stop int64) ([]string, error) {
+	return rds.Client.ZRange(ctx, key, start, stop).Result()
+}
+
+// ZRange 按分数从低到高获取成员, 出错时返回空切片
+func (rds RedisClient) ZRange(key string, start int64, stop int64) []string {
+	members, err := rds.ZRangeContext(rds.Context, key, start, stop)
+	if !rds.logIf("ZRange", err) {
+		return []string{}
+	}
+	return members
+}
+
+// ZRevRangeContext 按分数从高到低, 获取有序集合 key 中下标 start 到 stop 的成员, 适合排行榜
+func (rds RedisClient) ZRevRangeContext(ctx context.Context, key string, start int64, stop int64) ([]string, error) {
+	return rds.Client.ZRevRange(ctx, key, start, stop).Result()
+}
+
+// ZRevRange 按分数从高到低获取成员, 出错时返回空切片
+func (rds RedisClient) ZRevRange(key string, start int64, stop int64) []string {
+	members, err := rds.ZRevRangeContext(rds.Context, key, start, stop)
+	if !rds.logIf("ZRevRange", err) {
+		return []string{}
+	}
+	return members
+}
+
+// ZRangeByScoreContext 获取有序集合 key 中分数在 min 和 max 之间的成员
+// min 和 max 支持 Redis 的写法, 如 "-inf"、"+inf" 和表示不包含的 "(100"
+func (rds RedisClient) ZRangeByScoreContext(ctx context.Context, key string, min string, max string) ([]string, error) {
+	return rds.Client.ZRangeByScore(ctx, key, &redis.ZRangeBy{Min: min, Max: max}).Result()
+}
+
+// ZRangeByScore 获取分数在 min 和 max 之间的成员, 出错时返回空切片
+func (rds RedisClient) ZRangeByScore(key string, min string, max string) []string {
+	members, err := rds.ZRangeByScoreContext(rds.Context, key, min, max)
+	if !rds.logIf("ZRangeByScore", err) {
+		return []string{}
+	}
+	return members
+}
+
+// ZRemContext 从有序集合 key 移除成员, 返回移除的数量
+func (rds RedisClient) ZRemContext(ctx context.Context, key string, members ...interface{}) (int64, error) {
+	return rds.Client.ZRem(ctx, key, members...).Result()
+}
+
+// ZRem 从有序集合 key 移除成员
+func (rds RedisClient) ZRem(key string, members ...interface{}) bool {
+	_, err := rds.ZRemContext(rds.Context, key, members...)
+	return rds.logIf("ZRem", err)
+}
+
+// ZCardContext 获取有序集合 key 的成员数量
+func (rds RedisClient) ZCardContext(ctx context.Context, key string) (int64, error) {
+	return rds.Client.ZCard(ctx, key).Result()
+}
+
+// ZCard 获取有序集合 key 的成员数量
+func (rds RedisClient) ZCard(key string) int64 {
+	count, err := rds.ZCardContext(rds.Context, key)
+	rds.logIf("ZCard", err)
+	return count
+}
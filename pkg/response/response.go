@@ -60,6 +60,14 @@ func Abort403(c *gin.Context, msg ...string) {
 	})
 }
 
+// Abort429 响应 429, 未传参 msg 时使用默认消息
+// 请求过于频繁, 如触发接口限流、验证码发送限制时调用
+func Abort429(c *gin.Context, msg ...string) {
+	c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+		"message": defaultMessage("请求太频繁, 请稍后再试", msg...),
+	})
+}
+
 // Abort500 响应 500, 未传参 msg 时使用默认消息
 func Abort500(c *gin.Context, msg ...string) {
 	c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
@@ -115,13 +123,13 @@ func ValidationError(c *gin.Context, errors map[string][]string) {
 // 登录失败, jwt 解析失败时调用
 func Unauthorized(c *gin.Context, msg ...string) {
 	c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-		"message": defaultMessage("请求解析错误, 请确认请求格式是否正确. 上传文件请使用 multipart 标头, 参数请使用 JSON 格式.", msg...),
+		"message": defaultMessage("认证失败, 请确认令牌是否有效或重新登录", msg...),
 	})
 }
 
 // defaultMessage 内用的辅助函数, 用以支持默认参数默认值
 func defaultMessage(defaultMsg string, msg ...string) (message string) {
-	if len(msg) >= 0 {
+	if len(msg) > 0 {
 		message = msg[0]
 	} else {
 		message = defaultMsg
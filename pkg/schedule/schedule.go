@@ -0,0 +1,178 @@
+// Package schedule 定时任务, 按 cron 表达式在 app.timezone 时区执行
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"gohub/pkg/app"
+	"gohub/pkg/config"
+	"gohub/pkg/console"
+	"gohub/pkg/logger"
+	"gohub/pkg/redis"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Task 定时任务
+type Task struct {
+	Name    string       // 任务名称, 需唯一, 用以日志和分布式锁
+	Spec    string       // cron 表达式, 格式为: 分 时 日 月 星期
+	Handler func() error // 任务逻辑
+
+	schedule cron.Schedule
+}
+
+// tasks 已注册的定时任务
+var tasks []*Task
+
+// Add 注册定时任务, 默认每分钟执行, 可链式调用设置执行频率, 用法:
+//
+//	schedule.Add("clean-logs", CleanLogs).DailyAt("03:00")
+//	schedule.Add("daily-stats", DailyStats).Cron("30 1 * * *")
+func Add(name string, handler func() error) *Task {
+	task := &Task{
+		Name:    name,
+		Handler: handler,
+	}
+	tasks = append(tasks, task.Cron("* * * * *"))
+	return task
+}
+
+// Tasks 返回已注册的定时任务
+func Tasks() []*Task {
+	return tasks
+}
+
+// Cron 使用 cron 表达式设置执行频率, 表达式有误时退出程序
+func (task *Task) Cron(spec string) *Task {
+	schedule, err := cron.ParseStandard(spec)
+	console.ExitIf(err)
+
+	task.Spec = spec
+	task.schedule = schedule
+	return task
+}
+
+// EveryMinute 每分钟执行
+func (task *Task) EveryMinute() *Task {
+	return task.Cron("* * * * *")
+}
+
+// EveryFiveMinutes 每五分钟执行
+func (task *Task) EveryFiveMinutes() *Task {
+	return task.Cron("*/5 * * * *")
+}
+
+// Hourly 每小时的第 0 分钟执行
+func (task *Task) Hourly() *Task {
+	return task.Cron("0 * * * *")
+}
+
+// HourlyAt 每小时的第 minute 分钟执行
+func (task *Task) HourlyAt(minute int) *Task {
+	return task.Cron(fmt.Sprintf("%d * * * *", minute))
+}
+
+// Daily 每天 00:00 执行
+func (task *Task) Daily() *Task {
+	return task.Cron("0 0 * * *")
+}
+
+// DailyAt 每天的指定时间执行, 格式为 "15:04", 如 DailyAt("03:00")
+func (task *Task) DailyAt(clock string) *Task {
+	t, err := time.Parse("15:04", clock)
+	console.ExitIf(err)
+	return task.Cron(fmt.Sprintf("%d %d * * *", t.Minute(), t.Hour()))
+}
+
+// Weekly 每周日 00:00 执行
+func (task *Task) Weekly() *Task {
+	return task.Cron("0 0 * * 0")
+}
+
+// Monthly 每月 1 日 00:00 执行
+func (task *Task) Monthly() *Task {
+	return task.Cron("0 0 1 * *")
+}
+
+// Next 返回 after 之后下一次执行的时间
+func (task *Task) Next(after time.Time) time.Time {
+	return task.schedule.Next(after)
+}
+
+// IsDue 判断任务在 tick 这一分钟是否需要执行
+func (task *Task) IsDue(tick time.Time) bool {
+	return task.schedule.Next(tick.Add(-time.Second)).Equal(tick)
+}
+
+// Run 每分钟检查一次并执行到期的任务, 阻塞运行, 直到 ctx 被取消
+// 时间按 app.timezone 计算, 退出前会等待正在执行的任务完成
+func Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		// 等待到下一分钟整点
+		now := app.TimenowInTimezone()
+		tick := now.Truncate(time.Minute).Add(time.Minute)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(tick.Sub(now)):
+		}
+
+		// 各任务并行执行, 避免耗时的任务推迟其他任务
+		for _, task := range tasks {
+			if !task.IsDue(tick) {
+				continue
+			}
+			wg.Add(1)
+			go func(task *Task) {
+				defer wg.Done()
+				task.run(tick)
+			}(task)
+		}
+	}
+}
+
+// run 执行任务, 多个实例同时运行 schedule:run 时, 通过 Redis 锁保证同一时刻只有一个实例执行
+func (task *Task) run(tick time.Time) {
+
+	lockKey := fmt.Sprintf("%s:schedule:%s:%s", config.GetString("app.name"), task.Name, tick.Format("200601021504"))
+	locked, err := redis.Redis.SetNXContext(redis.Redis.Context, lockKey, 1, time.Hour)
+	if err != nil {
+		logger.ErrorString("定时任务", task.Name+" 获取锁失败", err.Error())
+		return
+	}
+	if !locked {
+		logger.DebugString("定时任务", task.Name, "已由其他实例执行")
+		return
+	}
+
+	start := time.Now()
+	if err := task.handle(); err != nil {
+		logger.ErrorJSON("定时任务", "执行失败", map[string]string{
+			"task":  task.Name,
+			"error": err.Error(),
+		})
+		return
+	}
+
+	logger.InfoJSON("定时任务", "执行完成", map[string]string{
+		"task":     task.Name,
+		"duration": time.Since(start).String(),
+	})
+}
+
+// handle 执行任务逻辑, 任务 panic 时视为执行失败, 避免进程退出
+func (task *Task) handle() (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return task.Handler()
+}
@@ -0,0 +1,104 @@
+package schedule
+
+import (
+	"errors"
+	"gohub/pkg/config"
+	"gohub/pkg/logger"
+	"gohub/pkg/redis"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	logger.Logger = zap.NewNop()
+	config.Set("app.name", "test")
+	os.Exit(m.Run())
+}
+
+func TestIsDue(t *testing.T) {
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	if err != nil {
+		t.Skip(err)
+	}
+	at := func(value string) time.Time {
+		tick, err := time.ParseInLocation("2006-01-02 15:04", value, shanghai)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tick
+	}
+
+	tests := []struct {
+		name string
+		task *Task
+		tick time.Time
+		want bool
+	}{
+		{"every minute", (&Task{}).EveryMinute(), at("2022-06-20 16:47"), true},
+		{"every five minutes on time", (&Task{}).EveryFiveMinutes(), at("2022-06-20 16:45"), true},
+		{"every five minutes off time", (&Task{}).EveryFiveMinutes(), at("2022-06-20 16:47"), false},
+		{"hourly", (&Task{}).Hourly(), at("2022-06-20 17:00"), true},
+		{"hourly at", (&Task{}).HourlyAt(15), at("2022-06-20 17:15"), true},
+		{"hourly at off time", (&Task{}).HourlyAt(15), at("2022-06-20 17:16"), false},
+		{"daily", (&Task{}).Daily(), at("2022-06-21 00:00"), true},
+		{"daily at", (&Task{}).DailyAt("03:00"), at("2022-06-21 03:00"), true},
+		{"daily at in another timezone", (&Task{}).DailyAt("03:00"), at("2022-06-21 03:00").In(time.UTC), false},
+		{"weekly on sunday", (&Task{}).Weekly(), at("2022-06-19 00:00"), true},
+		{"weekly on monday", (&Task{}).Weekly(), at("2022-06-20 00:00"), false},
+		{"monthly", (&Task{}).Monthly(), at("2022-07-01 00:00"), true},
+		{"monthly off day", (&Task{}).Monthly(), at("2022-07-02 00:00"), false},
+		{"cron", (&Task{}).Cron("30 1 * * 1-5"), at("2022-06-20 01:30"), true},
+		{"cron weekend", (&Task{}).Cron("30 1 * * 1-5"), at("2022-06-25 01:30"), false},
+	}
+	for _, tt := range tests {
+		if got := tt.task.IsDue(tt.tick); got != tt.want {
+			t.Errorf("%s: %q IsDue(%v) = %v, want %v", tt.name, tt.task.Spec, tt.tick, got, tt.want)
+		}
+	}
+}
+
+func TestRunOncePerTick(t *testing.T) {
+	mr := miniredis.RunT(t)
+	redis.Redis = redis.NewClient(mr.Addr(), "", "", 0)
+
+	calls := 0
+	task := Add("count", func() error {
+		calls++
+		return nil
+	})
+	tick := time.Date(2022, 6, 20, 16, 47, 0, 0, time.UTC)
+
+	// 多个实例在同一分钟执行, 只有一个实例获得锁
+	task.run(tick)
+	task.run(tick)
+	if calls != 1 {
+		t.Errorf("calls in the same tick = %d, want 1", calls)
+	}
+
+	task.run(tick.Add(time.Minute))
+	if calls != 2 {
+		t.Errorf("calls after the next tick = %d, want 2", calls)
+	}
+}
+
+func TestHandleRecoversPanic(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler func() error
+		wantErr bool
+	}{
+		{"success", func() error { return nil }, false},
+		{"error", func() error { return errors.New("failed") }, true},
+		{"panic", func() error { panic("boom") }, true},
+	}
+	for _, tt := range tests {
+		task := &Task{Name: tt.name, Handler: tt.handler}
+		if err := task.handle(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: handle() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
@@ -1,55 +1,151 @@
 package sms
 
 import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"gohub/pkg/helpers"
 	"gohub/pkg/logger"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
 
-	aliyunsmsclient "github.com/KenmyZhang/aliyun-communicate"
+// 阿里云短信接口信息
+const (
+	aliyunEndpoint = "https://dysmsapi.aliyuncs.com/"
+	aliyunVersion  = "2017-05-25"
+	aliyunRegion   = "cn-hangzhou"
 )
 
 // Aliyun 实现 sms.Driver interface
+// 接口使用 RPC 风格的 HMAC-SHA1 签名, 文档: https://help.aliyun.com/document_detail/315526.html
 type Aliyun struct{}
 
-func (a *Aliyun) Send(phone string, message Message, config map[string]string) bool {
-	smsClient := aliyunsmsclient.New("http://dysmsapi.aliyuncs.com/")
+// aliyunTemporaryCodes 阿里云返回的可重试错误码, 服务商系统故障或接口限频
+// 注意 isv.BUSINESS_LIMIT_CONTROL 是单个手机号的频率限制, 重试也不会成功
+var aliyunTemporaryCodes = map[string]bool{
+	"isp.SYSTEM_ERROR":        true,
+	"isp.SERVICE_UNAVAILABLE": true,
+	"Throttling.User":         true,
+}
 
-	templateParm, err := json.Marshal(message.Data)
+// aliyunErrorKinds 阿里云错误码对应的错误类型
+var aliyunErrorKinds = map[string]error{
+	"InvalidAccessKeyId.NotFound": ErrAuthFailed,
+	"SignatureDoesNotMatch":       ErrAuthFailed,
+	"isv.MOBILE_NUMBER_ILLEGAL":   ErrInvalidPhone,
+	"isv.BLACK_KEY_CONTROL_LIMIT": ErrInvalidPhone,
+}
+
+// aliyunResponse 接口响应
+type aliyunResponse struct {
+	RequestId string
+	Code      string
+	Message   string
+	BizId     string
+}
+
+func (a *Aliyun) Send(phone string, message Message, config map[string]string) error {
 
+	templateParm, err := json.Marshal(message.Data)
 	if err != nil {
 		logger.ErrorString("短信[阿里云]", "解析绑定失败", err.Error())
-		return false
+		return err
 	}
 
 	logger.DebugJSON("短信[阿里云]", "配置信息", config)
 
-	result, err := smsClient.Execute(
-		config["access_key_id"],
-		config["access_key_secret"],
-		phone,
-		config["sign_name"],
-		message.Template,
-		string(templateParm),
-	)
+	params := url.Values{}
+	params.Set("AccessKeyId", config["access_key_id"])
+	params.Set("Action", "SendSms")
+	params.Set("Format", "JSON")
+	params.Set("PhoneNumbers", phone)
+	params.Set("RegionId", aliyunRegion)
+	params.Set("SignName", config["sign_name"])
+	params.Set("SignatureMethod", "HMAC-SHA1")
+	params.Set("SignatureNonce", helpers.RandomString(32))
+	params.Set("SignatureVersion", "1.0")
+	params.Set("TemplateCode", message.Template)
+	params.Set("TemplateParam", string(templateParm))
+	params.Set("Timestamp", time.Now().UTC().Format("2006-01-02T15:04:05Z"))
+	params.Set("Version", aliyunVersion)
 
-	logger.DebugJSON("短信[阿里云]", "请求内容", smsClient.Request)
-	logger.DebugJSON("短信[阿里云]", "接口响应", result)
+	logger.DebugJSON("短信[阿里云]", "请求内容", params)
+
+	query := aliyunCanonicalQuery(params)
+	signature := aliyunSign(config["access_key_secret"], http.MethodGet, query)
+	endpoint := aliyunEndpoint + "?Signature=" + aliyunPercentEncode(signature) + "&" + query
 
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(endpoint)
 	if err != nil {
+		// 请求未能完成, 如网络超时
 		logger.ErrorString("短信[阿里云]", "发送失败", err.Error())
-		return false
+		return Temporary(&SendError{Kind: ErrConnectionFailed, Err: err})
 	}
+	defer resp.Body.Close()
 
-	resultJSON, err := json.Marshal(result)
-	if err != nil {
+	var result aliyunResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
 		logger.ErrorString("短信[阿里云]", "解析响应 JSON 错误", err.Error())
-		return false
+		// 网关故障时可能返回非 JSON 的响应
+		if resp.StatusCode >= 500 {
+			return Temporary(err)
+		}
+		return err
 	}
 
-	if result.IsSuccessful() {
+	logger.DebugJSON("短信[阿里云]", "接口响应", result)
+
+	if result.Code == "OK" {
 		logger.DebugString("短信[阿里云]", "发送成功", "")
-		return true
-	} else {
-		logger.ErrorString("短信[阿里云]", "服务商返回错误", string(resultJSON))
-		return false
+		return nil
 	}
+
+	logger.ErrorJSON("短信[阿里云]", "服务商返回错误", result)
+	err = errors.New(result.Code + ": " + result.Message)
+	if kind, ok := aliyunErrorKinds[result.Code]; ok {
+		return &SendError{Kind: kind, Err: err}
+	}
+	if aliyunTemporaryCodes[result.Code] {
+		return Temporary(err)
+	}
+	return err
+}
+
+// aliyunCanonicalQuery 按参数名排序并编码, 得到规范化的请求字符串
+func aliyunCanonicalQuery(params url.Values) string {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, aliyunPercentEncode(key)+"="+aliyunPercentEncode(params.Get(key)))
+	}
+	return strings.Join(pairs, "&")
+}
+
+// aliyunSign 计算签名, 密钥为 AccessKeySecret 加上 "&"
+func aliyunSign(secret string, method string, canonicalQuery string) string {
+	stringToSign := method + "&" + aliyunPercentEncode("/") + "&" + aliyunPercentEncode(canonicalQuery)
+	mac := hmac.New(sha1.New, []byte(secret+"&"))
+	mac.Write([]byte(stringToSign))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// aliyunPercentEncode 阿里云要求的 URL 编码, 空格编码为 %20, * 编码为 %2A, ~ 不编码
+func aliyunPercentEncode(value string) string {
+	encoded := url.QueryEscape(value)
+	encoded = strings.ReplaceAll(encoded, "+", "%20")
+	encoded = strings.ReplaceAll(encoded, "*", "%2A")
+	encoded = strings.ReplaceAll(encoded, "%7E", "~")
+	return encoded
 }
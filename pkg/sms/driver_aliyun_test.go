@@ -0,0 +1,51 @@
+package sms
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestAliyunPercentEncode(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"abc-_.~", "abc-_.~"},
+		{"a b", "a%20b"},
+		{"a*b", "a%2Ab"},
+		{"a+b", "a%2Bb"},
+		{"阿里", "%E9%98%BF%E9%87%8C"},
+	}
+	for _, tt := range tests {
+		if got := aliyunPercentEncode(tt.in); got != tt.want {
+			t.Errorf("aliyunPercentEncode(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+// TestAliyunSign 使用阿里云签名文档中的示例参数和签名结果
+func TestAliyunSign(t *testing.T) {
+	params := url.Values{}
+	for key, value := range map[string]string{
+		"AccessKeyId":      "testId",
+		"Action":           "SendSms",
+		"Format":           "XML",
+		"OutId":            "123",
+		"PhoneNumbers":     "15300000001",
+		"RegionId":         "cn-hangzhou",
+		"SignName":         "阿里云短信测试专用",
+		"SignatureMethod":  "HMAC-SHA1",
+		"SignatureNonce":   "45e25e9b-0a6f-4070-8c85-2956eda1b466",
+		"SignatureVersion": "1.0",
+		"TemplateCode":     "SMS_71390007",
+		"TemplateParam":    `{"customer":"test"}`,
+		"Timestamp":        "2017-07-12T02:42:19Z",
+		"Version":          "2017-05-25",
+	} {
+		params.Set(key, value)
+	}
+
+	got := aliyunSign("testSecret", "GET", aliyunCanonicalQuery(params))
+	if want := "zJDF+Lrzhj/ThnlvIToysFRq6t4="; got != want {
+		t.Errorf("aliyunSign() = %q, want %q", got, want)
+	}
+}
@@ -2,6 +2,7 @@ package sms
 
 type Driver interface {
 
-	// 发送短信
-	Send(phone string, message Message, config map[string]string) bool
+	// 发送短信, 发送成功返回 nil
+	// 网络超时、服务商系统繁忙等可重试的错误, 请使用 Temporary 包装后返回
+	Send(phone string, message Message, config map[string]string) error
 }
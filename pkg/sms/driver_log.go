@@ -0,0 +1,19 @@
+package sms
+
+import (
+	"gohub/pkg/logger"
+)
+
+// Log 实现 sms.Driver interface, 只将短信内容写入日志, 不会真正发送
+// 适用于本地开发和测试环境
+type Log struct{}
+
+func (l *Log) Send(phone string, message Message, config map[string]string) error {
+	logger.InfoJSON("短信[日志]", "发送短信", map[string]interface{}{
+		"phone":    phone,
+		"template": message.Template,
+		"data":     message.Data,
+		"content":  message.Content,
+	})
+	return nil
+}
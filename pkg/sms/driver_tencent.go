@@ -0,0 +1,193 @@
+package sms
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gohub/pkg/logger"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// 腾讯云短信 API 3.0 的接口信息
+const (
+	tencentHost    = "sms.tencentcloudapi.com"
+	tencentService = "sms"
+	tencentVersion = "2021-01-11"
+	tencentAction  = "SendSms"
+)
+
+// tencentInvalidPhoneCodes 手机号有误或无法接收短信的错误码
+var tencentInvalidPhoneCodes = map[string]bool{
+	"InvalidParameterValue.IncorrectPhoneNumber": true,
+	"FailedOperation.PhoneNumberInBlacklist":     true,
+}
+
+// Tencent 实现 sms.Driver interface
+// 接口使用 TC3-HMAC-SHA256 签名, 文档: https://cloud.tencent.com/document/api/382/55981
+type Tencent struct{}
+
+// tencentResponse 接口响应, 只解析用到的字段
+type tencentResponse struct {
+	Response struct {
+		SendStatusSet []struct {
+			PhoneNumber string
+			Code        string
+			Message     string
+		}
+		Error *struct {
+			Code    string
+			Message string
+		}
+		RequestId string
+	}
+}
+
+func (t *Tencent) Send(phone string, message Message, config map[string]string) error {
+
+	// 腾讯云的模板参数是有序数组, 按 message.Data 的 key 排序后依次传入
+	keys := make([]string, 0, len(message.Data))
+	for key := range message.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	templateParams := make([]string, 0, len(keys))
+	for _, key := range keys {
+		templateParams = append(templateParams, message.Data[key])
+	}
+
+	// 国内手机号需加上 +86 前缀
+	if !strings.HasPrefix(phone, "+") {
+		phone = "+86" + phone
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"PhoneNumberSet":   []string{phone},
+		"SmsSdkAppId":      config["sdk_app_id"],
+		"SignName":         config["sign_name"],
+		"TemplateId":       message.Template,
+		"TemplateParamSet": templateParams,
+	})
+	if err != nil {
+		logger.ErrorString("短信[腾讯云]", "解析绑定失败", err.Error())
+		return err
+	}
+
+	logger.DebugJSON("短信[腾讯云]", "请求内容", string(payload))
+
+	req, err := http.NewRequest(http.MethodPost, "https://"+tencentHost, bytes.NewReader(payload))
+	if err != nil {
+		logger.ErrorString("短信[腾讯云]", "创建请求失败", err.Error())
+		return err
+	}
+	t.sign(req, payload, config)
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		logger.ErrorString("短信[腾讯云]", "发送失败", err.Error())
+		return Temporary(&SendError{Kind: ErrConnectionFailed, Err: err})
+	}
+	defer resp.Body.Close()
+
+	var result tencentResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		logger.ErrorString("短信[腾讯云]", "解析响应 JSON 错误", err.Error())
+		// 网关故障时可能返回非 JSON 的响应
+		if resp.StatusCode >= 500 {
+			return Temporary(err)
+		}
+		return err
+	}
+
+	logger.DebugJSON("短信[腾讯云]", "接口响应", result)
+
+	if apiErr := result.Response.Error; apiErr != nil {
+		logger.ErrorJSON("短信[腾讯云]", "服务商返回错误", apiErr)
+		err = errors.New(apiErr.Code + ": " + apiErr.Message)
+		switch {
+		case strings.HasPrefix(apiErr.Code, "AuthFailure"):
+			return &SendError{Kind: ErrAuthFailed, Err: err}
+		case tencentInvalidPhoneCodes[apiErr.Code]:
+			return &SendError{Kind: ErrInvalidPhone, Err: err}
+		case strings.HasPrefix(apiErr.Code, "InternalError") || apiErr.Code == "RequestLimitExceeded":
+			// 服务内部错误和接口限频可以重试
+			return Temporary(err)
+		}
+		return err
+	}
+	for _, status := range result.Response.SendStatusSet {
+		if status.Code != "Ok" {
+			logger.ErrorJSON("短信[腾讯云]", "服务商返回错误", status)
+			err = errors.New(status.Code + ": " + status.Message)
+			if tencentInvalidPhoneCodes[status.Code] {
+				return &SendError{Kind: ErrInvalidPhone, Err: err}
+			}
+			return err
+		}
+	}
+
+	logger.DebugString("短信[腾讯云]", "发送成功", "")
+	return nil
+}
+
+// sign 为请求添加公共参数和 TC3-HMAC-SHA256 签名
+func (t *Tencent) sign(req *http.Request, payload []byte, config map[string]string) {
+	now := time.Now().UTC()
+	timestamp := fmt.Sprintf("%d", now.Unix())
+	date := now.Format("2006-01-02")
+	contentType := "application/json; charset=utf-8"
+
+	// 1. 拼接规范请求串
+	canonicalRequest := strings.Join([]string{
+		http.MethodPost,
+		"/",
+		"",
+		"content-type:" + contentType + "\nhost:" + tencentHost + "\n",
+		"content-type;host",
+		sha256Hex(payload),
+	}, "\n")
+
+	// 2. 拼接待签名字符串
+	credentialScope := date + "/" + tencentService + "/tc3_request"
+	stringToSign := strings.Join([]string{
+		"TC3-HMAC-SHA256",
+		timestamp,
+		credentialScope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	// 3. 计算签名
+	secretDate := hmacSHA256([]byte("TC3"+config["secret_key"]), date)
+	secretService := hmacSHA256(secretDate, tencentService)
+	secretSigning := hmacSHA256(secretService, "tc3_request")
+	signature := hex.EncodeToString(hmacSHA256(secretSigning, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"TC3-HMAC-SHA256 Credential=%s/%s, SignedHeaders=content-type;host, Signature=%s",
+		config["secret_id"], credentialScope, signature,
+	))
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Host", tencentHost)
+	req.Header.Set("X-TC-Action", tencentAction)
+	req.Header.Set("X-TC-Timestamp", timestamp)
+	req.Header.Set("X-TC-Version", tencentVersion)
+	req.Header.Set("X-TC-Region", config["region"])
+}
+
+func sha256Hex(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, msg string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(msg))
+	return mac.Sum(nil)
+}
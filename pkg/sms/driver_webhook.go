@@ -0,0 +1,82 @@
+package sms
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"gohub/pkg/logger"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cast"
+)
+
+// Webhook 实现 sms.Driver interface, 将短信以 JSON 格式 POST 到配置的网关地址
+// 适用于自建短信网关, 或对接未内置的服务商. 请求体示例:
+//
+//	{
+//		"phone": "13800000000",
+//		"template": "SMS_TEMPLATE",
+//		"data": {"code": "123456"},
+//		"content": ""
+//	}
+//
+// 网关返回 2xx 状态码即视为发送成功, 返回 429 和 5xx 时视为临时性错误, 会进行重试
+// 返回 401、403 视为认证失败, 返回 422 视为手机号无法接收短信
+type Webhook struct{}
+
+func (w *Webhook) Send(phone string, message Message, config map[string]string) error {
+
+	body, err := json.Marshal(map[string]interface{}{
+		"phone":    phone,
+		"template": message.Template,
+		"data":     message.Data,
+		"content":  message.Content,
+	})
+	if err != nil {
+		logger.ErrorString("短信[Webhook]", "解析绑定失败", err.Error())
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, config["url"], bytes.NewReader(body))
+	if err != nil {
+		logger.ErrorString("短信[Webhook]", "创建请求失败", err.Error())
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	// 配置了 token 时, 网关可以据此校验请求来源
+	if token := config["token"]; len(token) > 0 {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	logger.DebugJSON("短信[Webhook]", "请求内容", string(body))
+
+	client := &http.Client{Timeout: time.Duration(cast.ToInt(config["timeout"])) * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		logger.ErrorString("短信[Webhook]", "发送失败", err.Error())
+		return Temporary(&SendError{Kind: ErrConnectionFailed, Err: err})
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(resp.Body)
+	logger.DebugJSON("短信[Webhook]", "接口响应", string(respBody))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		logger.ErrorString("短信[Webhook]", "服务商返回错误", resp.Status+" "+string(respBody))
+		err = errors.New("webhook 网关返回 " + resp.Status)
+		switch {
+		case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+			return &SendError{Kind: ErrAuthFailed, Err: err}
+		case resp.StatusCode == http.StatusUnprocessableEntity:
+			return &SendError{Kind: ErrInvalidPhone, Err: err}
+		case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+			return Temporary(err)
+		}
+		return err
+	}
+
+	logger.DebugString("短信[Webhook]", "发送成功", "")
+	return nil
+}
@@ -0,0 +1,42 @@
+package sms
+
+import (
+	"errors"
+	"gohub/pkg/notify"
+)
+
+var (
+	ErrNoDriver         error = errors.New("未配置可用的短信驱动")
+	ErrAuthFailed       error = errors.New("短信服务商认证失败")
+	ErrConnectionFailed error = errors.New("无法连接短信服务商")
+	ErrInvalidPhone     error = errors.New("手机号无法接收短信")
+)
+
+// SendError 发送失败的错误, Kind 为上面定义的错误类型之一, Err 为原始错误
+// 可以使用 errors.Is(err, sms.ErrInvalidPhone) 判断失败的类型
+type SendError = notify.SendError
+
+// TemporaryError 临时性的发送失败, 如网络超时、服务商系统繁忙, 稍后重试可能成功
+// 驱动返回此类错误时会按配置重试, 其他错误则直接切换到下一个驱动
+type TemporaryError struct {
+	Err error
+}
+
+func (e *TemporaryError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *TemporaryError) Unwrap() error {
+	return e.Err
+}
+
+// Temporary 将 err 标记为临时性错误
+func Temporary(err error) error {
+	return &TemporaryError{Err: err}
+}
+
+// IsTemporary 判断 err 是否为临时性错误
+func IsTemporary(err error) bool {
+	var temporaryErr *TemporaryError
+	return errors.As(err, &temporaryErr)
+}
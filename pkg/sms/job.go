@@ -0,0 +1,28 @@
+package sms
+
+import "gohub/pkg/queue"
+
+// SendJob 发送短信的队列任务, 用法:
+//
+//	queue.Dispatch(&sms.SendJob{Phone: phone, Message: message})
+type SendJob struct {
+	Phone   string
+	Message Message
+}
+
+func init() {
+	queue.Register(&SendJob{})
+}
+
+func (job *SendJob) Name() string {
+	return "sms.send"
+}
+
+func (job *SendJob) Handle() error {
+	return NewSMS().Send(job.Phone, job.Message)
+}
+
+// MaxTries 发送链已在各个服务商之间重试过, 队列只再重试一次
+func (job *SendJob) MaxTries() int {
+	return 2
+}
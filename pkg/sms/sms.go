@@ -2,7 +2,10 @@ package sms
 
 import (
 	"gohub/pkg/config"
+	"gohub/pkg/logger"
+	"strings"
 	"sync"
+	"time"
 )
 
 // Message 短信的结构体
@@ -12,9 +15,17 @@ type Message struct {
 	Content  string
 }
 
+// Channel 发送通道, 即一个已配置的驱动
+type Channel struct {
+	// Name 驱动名称, 同时也是驱动配置信息在 sms 下的 key, 如 sms.aliyun
+	Name   string
+	Driver Driver
+}
+
 // SMS 是我们发送短信的操作类
 type SMS struct {
-	Driver Driver
+	// Channels 按顺序尝试的发送通道, 前一个发送失败时切换到下一个
+	Channels []Channel
 }
 
 // once 单例模式
@@ -23,16 +34,104 @@ var once sync.Once
 // internalSMS 内部使用的 SMS 对象
 var internalSMS *SMS
 
-// NewSMS 单例模式获取
+// drivers 已注册的驱动, key 为 sms.driver 配置项可选的值
+var drivers = map[string]Driver{
+	"aliyun":  &Aliyun{},
+	"tencent": &Tencent{},
+	"webhook": &Webhook{},
+	"log":     &Log{},
+}
+
+// RegisterDriver 注册自定义驱动, 需在第一次调用 NewSMS 之前注册
+// 驱动的配置信息放在 sms.{name} 下
+func RegisterDriver(name string, driver Driver) {
+	drivers[name] = driver
+}
+
+// NewSMS 单例模式获取, 首选 sms.driver 配置的驱动, 失败时依次使用 sms.failover 里的驱动
 func NewSMS() *SMS {
 	once.Do(func() {
-		internalSMS = &SMS{
-			Driver: &Aliyun{},
+		internalSMS = &SMS{}
+
+		names := []string{config.GetString("sms.driver")}
+		for _, name := range strings.Split(config.GetString("sms.failover"), ",") {
+			if name = strings.TrimSpace(name); len(name) > 0 {
+				names = append(names, name)
+			}
+		}
+
+		for _, name := range names {
+			driver, ok := drivers[name]
+			if !ok {
+				logger.ErrorString("短信", "初始化驱动", "不支持的驱动: "+name)
+				continue
+			}
+			internalSMS.Channels = append(internalSMS.Channels, Channel{Name: name, Driver: driver})
 		}
 	})
 	return internalSMS
 }
 
-func (sms *SMS) Send(phone string, message Message) bool {
-	return sms.Driver.Send(phone, message, config.GetStringMapString("sms.aliyun"))
+// Send 按顺序使用各个通道发送短信, 有一个通道发送成功即返回 nil
+// 驱动返回临时性错误时, 按 sms.retry_times 和 sms.retry_backoff 在同一通道重试
+// 其他错误, 或重试次数用完, 则切换到下一个通道
+// 所有通道均失败时, 返回最后一个通道的错误, 可以使用 errors.Is 判断失败的类型
+func (sms *SMS) Send(phone string, message Message) (err error) {
+	if len(sms.Channels) == 0 {
+		logger.ErrorString("短信", "发送失败", "未配置可用的驱动")
+		return ErrNoDriver
+	}
+
+	for _, channel := range sms.Channels {
+		if err = sms.sendWithRetry(channel, phone, message); err == nil {
+			return nil
+		}
+	}
+
+	logger.ErrorJSON("短信", "所有通道均发送失败", map[string]string{
+		"phone": phone,
+		"error": err.Error(),
+	})
+	return err
+}
+
+// sendWithRetry 使用单个通道发送, 遇到临时性错误时以指数退避的间隔重试
+func (sms *SMS) sendWithRetry(channel Channel, phone string, message Message) (err error) {
+
+	// 每个驱动收到的是 sms.{驱动名称} 下的配置信息
+	// 未指定 message.Template 时, 使用驱动配置里的 template_code
+	driverConfig := config.GetStringMapString("sms." + channel.Name)
+	if len(message.Template) == 0 {
+		message.Template = driverConfig["template_code"]
+	}
+
+	retryTimes := config.GetInt("sms.retry_times")
+	backoff := time.Duration(config.GetInt64("sms.retry_backoff")) * time.Millisecond
+
+	for attempt := 1; ; attempt++ {
+		err = channel.Driver.Send(phone, message, driverConfig)
+		if err == nil {
+			logger.InfoJSON("短信", "发送成功", map[string]interface{}{
+				"driver":  channel.Name,
+				"phone":   phone,
+				"attempt": attempt,
+			})
+			return nil
+		}
+
+		logger.WarnJSON("短信", "发送失败", map[string]interface{}{
+			"driver":    channel.Name,
+			"phone":     phone,
+			"attempt":   attempt,
+			"temporary": IsTemporary(err),
+			"error":     err.Error(),
+		})
+
+		if !IsTemporary(err) || attempt > retryTimes {
+			return err
+		}
+
+		time.Sleep(backoff)
+		backoff *= 2
+	}
 }
@@ -0,0 +1,106 @@
+package sms
+
+import (
+	"errors"
+	"gohub/pkg/config"
+	"gohub/pkg/logger"
+	"os"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+// fakeDriver 按顺序返回 errs 中的错误, 用完后返回 nil
+type fakeDriver struct {
+	errs      []error
+	calls     int
+	templates []string
+}
+
+func (driver *fakeDriver) Send(phone string, message Message, config map[string]string) error {
+	driver.calls++
+	driver.templates = append(driver.templates, message.Template)
+	if driver.calls <= len(driver.errs) {
+		return driver.errs[driver.calls-1]
+	}
+	return nil
+}
+
+func TestMain(m *testing.M) {
+	logger.Logger = zap.NewNop()
+	config.Set("sms.retry_times", 2)
+	config.Set("sms.retry_backoff", 0)
+	config.Set("sms.primary", map[string]interface{}{"template_code": "SMS_PRIMARY"})
+	config.Set("sms.backup", map[string]interface{}{"template_code": "SMS_BACKUP"})
+	os.Exit(m.Run())
+}
+
+func TestSendFailover(t *testing.T) {
+	permanent := &SendError{Kind: ErrInvalidPhone, Err: errors.New("isv.MOBILE_NUMBER_ILLEGAL")}
+	temporary := Temporary(&SendError{Kind: ErrConnectionFailed, Err: errors.New("timeout")})
+	auth := &SendError{Kind: ErrAuthFailed, Err: errors.New("401")}
+
+	tests := []struct {
+		name        string
+		primaryErrs []error
+		backupErrs  []error
+		wantCalls   [2]int
+		wantErr     error
+	}{
+		{"primary succeeds", nil, nil, [2]int{1, 0}, nil},
+		{"permanent error switches channel", []error{permanent}, nil, [2]int{1, 1}, nil},
+		{"temporary error retries", []error{temporary, temporary}, nil, [2]int{3, 0}, nil},
+		{"retries exhausted switches channel", []error{temporary, temporary, temporary}, nil, [2]int{3, 1}, nil},
+		{"temporary then permanent switches channel", []error{temporary, permanent}, nil, [2]int{2, 1}, nil},
+		{"all channels fail", []error{permanent}, []error{auth}, [2]int{1, 1}, ErrAuthFailed},
+	}
+	for _, tt := range tests {
+		primary := &fakeDriver{errs: tt.primaryErrs}
+		backup := &fakeDriver{errs: tt.backupErrs}
+		sms := &SMS{Channels: []Channel{
+			{Name: "primary", Driver: primary},
+			{Name: "backup", Driver: backup},
+		}}
+
+		err := sms.Send("13800000000", Message{Data: map[string]string{"code": "123456"}})
+		if tt.wantErr == nil && err != nil || tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: Send() error = %v, want %v", tt.name, err, tt.wantErr)
+		}
+		if got := [2]int{primary.calls, backup.calls}; got != tt.wantCalls {
+			t.Errorf("%s: calls = %v, want %v", tt.name, got, tt.wantCalls)
+		}
+	}
+}
+
+func TestSendTemplate(t *testing.T) {
+	primary := &fakeDriver{errs: []error{errors.New("rejected")}}
+	backup := &fakeDriver{}
+	sms := &SMS{Channels: []Channel{
+		{Name: "primary", Driver: primary},
+		{Name: "backup", Driver: backup},
+	}}
+
+	// 未指定模板时, 每个通道使用各自配置的 template_code
+	if err := sms.Send("13800000000", Message{}); err != nil {
+		t.Fatal(err)
+	}
+	if primary.templates[0] != "SMS_PRIMARY" || backup.templates[0] != "SMS_BACKUP" {
+		t.Errorf("templates = %v, %v, want SMS_PRIMARY, SMS_BACKUP", primary.templates, backup.templates)
+	}
+
+	// 指定模板时, 所有通道使用同一模板
+	backup.templates = nil
+	primary.calls = 0
+	if err := sms.Send("13800000000", Message{Template: "SMS_CUSTOM"}); err != nil {
+		t.Fatal(err)
+	}
+	if backup.templates[0] != "SMS_CUSTOM" {
+		t.Errorf("template = %q, want SMS_CUSTOM", backup.templates[0])
+	}
+}
+
+func TestSendWithoutChannels(t *testing.T) {
+	if err := (&SMS{}).Send("13800000000", Message{}); err != ErrNoDriver {
+		t.Errorf("Send() error = %v, want %v", err, ErrNoDriver)
+	}
+}
@@ -1,5 +1,7 @@
 package verifycode
 
+import "time"
+
 type Store interface {
 
 	// 保存验证码
@@ -10,4 +12,16 @@ type Store interface {
 
 	// 检查验证码
 	Verify(id, answer string, clear bool) bool
+
+	// 删除验证码
+	Forget(id string) bool
+
+	// 仅在 id 不存在时保存, 保存成功返回 true, 用以实现重发冷却时间
+	SetIfNotExists(id string, value string, expiration time.Duration) bool
+
+	// 计数器加一并返回新的值, 计数器首次创建时设置过期时间
+	Increment(id string, expiration time.Duration) int64
+
+	// 计数器减一, 用以撤销未实际发送的计数
+	Decrement(id string) bool
 }
@@ -3,10 +3,24 @@ package verifycode
 import (
 	"gohub/pkg/app"
 	"gohub/pkg/config"
+	"gohub/pkg/logger"
 	"gohub/pkg/redis"
 	"time"
+
+	"github.com/spf13/cast"
 )
 
+// incrementScript 计数加一, 第一次计数时设置过期时间
+// 计数和设置过期时间在同一个脚本中执行, 不会留下永不过期的计数
+// KEYS[1] 为计数的 key, ARGV[1] 为过期时间 (毫秒)
+var incrementScript = redis.NewScript(`
+local count = redis.call('incr', KEYS[1])
+if count == 1 or redis.call('pttl', KEYS[1]) == -1 then
+	redis.call('pexpire', KEYS[1], ARGV[1])
+end
+return count
+`)
+
 type RedisStore struct {
 	RedisClient *redis.RedisClient
 	KeyPrefix   string
@@ -37,3 +51,28 @@ func (s *RedisStore) Verify(key, answer string, clear bool) bool {
 	v := s.Get(key, clear)
 	return v == answer
 }
+
+// Forget 实现 verifycode.Store interface 的 Forget 方法
+func (s *RedisStore) Forget(key string) bool {
+	return s.RedisClient.Del(s.KeyPrefix + key)
+}
+
+// SetIfNotExists 实现 verifycode.Store interface 的 SetIfNotExists 方法
+func (s *RedisStore) SetIfNotExists(key string, value string, expiration time.Duration) bool {
+	return s.RedisClient.SetNX(s.KeyPrefix+key, value, expiration)
+}
+
+// Increment 实现 verifycode.Store interface 的 Increment 方法
+func (s *RedisStore) Increment(key string, expiration time.Duration) int64 {
+	count, err := s.RedisClient.RunContext(s.RedisClient.Context, incrementScript, []string{s.KeyPrefix + key}, expiration.Milliseconds())
+	if err != nil {
+		logger.ErrorString("验证码", "Increment", err.Error())
+		return 0
+	}
+	return cast.ToInt64(count)
+}
+
+// Decrement 实现 verifycode.Store interface 的 Decrement 方法
+func (s *RedisStore) Decrement(key string) bool {
+	return s.RedisClient.Decrement(s.KeyPrefix + key)
+}
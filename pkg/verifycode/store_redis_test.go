@@ -0,0 +1,41 @@
+package verifycode
+
+import (
+	"gohub/pkg/logger"
+	"gohub/pkg/redis"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"go.uber.org/zap"
+)
+
+func TestRedisStoreIncrement(t *testing.T) {
+	logger.Logger = zap.NewNop()
+	mr := miniredis.RunT(t)
+	store := &RedisStore{RedisClient: redis.NewClient(mr.Addr(), "", "", 0), KeyPrefix: "test:verifycode"}
+
+	for i := int64(1); i <= 3; i++ {
+		if got := store.Increment("attempts:a", time.Minute); got != i {
+			t.Fatalf("Increment() = %d, want %d", got, i)
+		}
+	}
+	if ttl := mr.TTL("test:verifycodeattempts:a"); ttl != time.Minute {
+		t.Errorf("TTL after increments = %v, want %v", ttl, time.Minute)
+	}
+
+	// 之前非原子操作遗留的永不过期计数, 下一次计数时补上过期时间
+	mr.Set("test:verifycodeattempts:b", "5")
+	if got := store.Increment("attempts:b", time.Minute); got != 6 {
+		t.Errorf("Increment() = %d, want 6", got)
+	}
+	if ttl := mr.TTL("test:verifycodeattempts:b"); ttl != time.Minute {
+		t.Errorf("TTL of persisted counter = %v, want %v", ttl, time.Minute)
+	}
+
+	// 过期后重新计数
+	mr.FastForward(time.Minute)
+	if got := store.Increment("attempts:a", time.Minute); got != 1 {
+		t.Errorf("Increment() after expiry = %d, want 1", got)
+	}
+}
@@ -2,17 +2,24 @@
 package verifycode
 
 import (
-	"fmt"
+	"errors"
 	"gohub/pkg/app"
 	"gohub/pkg/config"
 	"gohub/pkg/helpers"
 	"gohub/pkg/logger"
 	"gohub/pkg/mail"
+	"gohub/pkg/queue"
 	"gohub/pkg/redis"
 	"gohub/pkg/sms"
 
 	"strings"
 	"sync"
+	"time"
+)
+
+var (
+	ErrSendTooFrequent    error = errors.New("验证码发送过于频繁, 请稍后再试")
+	ErrDailyLimitExceeded error = errors.New("今日验证码发送次数已达上限, 请明天再试")
 )
 
 type VerifyCode struct {
@@ -36,58 +43,172 @@ func NewVerifyCode() *VerifyCode {
 	return internalVerifyCode
 }
 
-// SendSMS 发送短信验证码, 调试实例:
-// 		verifycode.NewVerifyCode().SendSMS(request.Phone)
-func (vc *VerifyCode) SendSms(phone string) bool {
-	// 生成验证码
-	code := vc.generateVerifyCode(phone)
+// 验证码通过队列发送, 请求无需等待服务商的响应, 以及驱动之间的切换和重试
+// 服务商发送失败时, 由队列重试并记录到日志和失败列表, 不会返回给用户
+// 同步返回的错误只有发送频率限制, 以及推送到队列失败 (queue.connection 为 sync 时为发送失败)
+
+// SendSMS 发送短信验证码, clientIP 用以限制同一 IP 每日的发送次数, 调试实例:
+// 		verifycode.NewVerifyCode().SendSms(request.Phone, c.ClientIP())
+func (vc *VerifyCode) SendSms(phone string, clientIP string) error {
 
 	// 方便本地和 API 自动测试
-	if !app.IsProduction() && strings.HasPrefix(phone, config.GetString("verifycode.debug_phone_prefix")) {
-		return true
+	if vc.isDebugKey(phone) {
+		vc.generateVerifyCode(phone)
+		return nil
 	}
 
-	// 发送短信
-	return sms.NewSMS().Send(phone, sms.Message{
-		Template: config.GetString("sms.aliyun.template_code"),
-		Data:     map[string]string{"code": code},
-	})
-}
+	// 检查发送频率和每日次数
+	rollback, err := vc.throttle(phone, clientIP)
+	if err != nil {
+		return err
+	}
 
-func (vc *VerifyCode) SendEmail(email string) error {
+	// 生成验证码
+	code := vc.generateVerifyCode(phone)
 
-	// 1. 生成验证码
-	code := vc.generateVerifyCode(email)
+	// 推送到队列发送, 未指定 Template, 使用当前驱动配置的 template_code
+	if err = queue.Dispatch(&sms.SendJob{
+		Phone:   phone,
+		Message: sms.Message{Data: map[string]string{"code": code}},
+	}); err != nil {
+		// 推送失败时, 撤销本次发送的记录, 允许用户立即重试
+		rollback()
+		return err
+	}
+	return nil
+}
+
+// SendEmail 发送邮件验证码, clientIP 用以限制同一 IP 每日的发送次数
+func (vc *VerifyCode) SendEmail(email string, clientIP string) error {
 
-	// 2. 做环境判断,方便测试
-	if !app.IsProduction() && strings.HasSuffix(email, config.GetString("verifycode.debug_email_suffix")) {
+	// 1. 做环境判断,方便测试
+	if vc.isDebugKey(email) {
+		vc.generateVerifyCode(email)
 		return nil
 	}
 
-	// 3. 发送邮件
-	content := fmt.Sprintf("<h1> 您的邮件验证码是: %v </h1>", code)
-	mail.NewMailer().Send(mail.Email{
-		From: mail.From{
-			Address: config.GetString("mail.from.address"),
-			Name:    config.GetString("mail.from.name"),
+	// 2. 检查发送频率和每日次数
+	rollback, err := vc.throttle(email, clientIP)
+	if err != nil {
+		return err
+	}
+
+	// 3. 生成验证码
+	code := vc.generateVerifyCode(email)
+
+	// 4. 推送到队列发送邮件, 模板为 resources/mails/verifycode.html
+	if err = queue.Dispatch(&mail.SendTemplateJob{
+		To:       []string{email},
+		Template: "verifycode",
+		Data: map[string]interface{}{
+			"code":        code,
+			"expire_time": config.GetInt("verifycode.expire_time"),
 		},
-		To:      []string{email},
-		Subject: "您的验证码",
-		HTML:    []byte(content),
-	})
+	}); err != nil {
+		// 推送失败时, 撤销本次发送的记录, 允许用户立即重试
+		rollback()
+		return err
+	}
 	return nil
 }
 
 // CheckAnswer 检查用户提交的验证码是否正确, key 可以是手机号 或者 email
+// 输错次数达到 verifycode.max_failed_attempts 后, 验证码作废, 需重新获取
 func (vc *VerifyCode) CheckAnswer(key string, answer string) bool {
 	logger.DebugJSON("验证码", "检查验证码", map[string]string{key: answer})
 
 	// 方便开发, 在非生产环境下, 具备特殊前缀的手机号和 email 后缀, 会直接验证成功
-	if !app.IsProduction() && (strings.HasSuffix(key, config.GetString("verifycode.debug_email_suffix")) ||
-		strings.HasPrefix(key, config.GetString("verifycode.debug_phone_prefix"))) {
+	if vc.isDebugKey(key) {
 		return true
 	}
-	return vc.Store.Verify(key, answer, false)
+
+	// 验证码不存在, 已过期或已作废
+	code := vc.Store.Get(key, false)
+	if len(code) == 0 {
+		return false
+	}
+	if code == answer {
+		return true
+	}
+
+	// 记录输错次数, 达到上限时作废验证码
+	maxAttempts := config.GetInt64("verifycode.max_failed_attempts")
+	expireTime := time.Minute * time.Duration(config.GetInt64("verifycode.expire_time"))
+	if maxAttempts > 0 && vc.Store.Increment(attemptsKey(key), expireTime) >= maxAttempts {
+		logger.WarnString("验证码", "输错次数达到上限", key)
+		vc.Store.Forget(key)
+		vc.Store.Forget(attemptsKey(key))
+	}
+	return false
+}
+
+// Consume 作废验证码, 在验证通过且注册、登录、重置密码等操作成功后调用
+// CheckAnswer 不会删除验证码, 这样表单其他字段验证出错时, 用户可以修改后使用同一验证码再次提交
+func (vc *VerifyCode) Consume(key string) {
+	vc.Store.Forget(key)
+	vc.Store.Forget(attemptsKey(key))
+}
+
+// throttle 检查 key 和 clientIP 的每日发送次数, 以及 key 的重发间隔
+// 通过时已记录本次发送, 返回的 rollback 用以在发送失败时撤销记录, 允许用户立即重试
+// 被拒绝的请求不会留下任何记录, 不会因为一次被拒绝而触发另一项限制
+func (vc *VerifyCode) throttle(key string, clientIP string) (rollback func(), err error) {
+
+	var undo []func()
+	rollback = func() {
+		for _, fn := range undo {
+			fn()
+		}
+	}
+
+	// 1. 重发间隔内, 不允许再次发送, 只检查不设置, 通过所有检查后再设置
+	interval := time.Second * time.Duration(config.GetInt64("verifycode.resend_interval"))
+	if interval > 0 && len(vc.Store.Get(cooldownKey(key), false)) > 0 {
+		return nil, ErrSendTooFrequent
+	}
+
+	// 2. 每日发送次数, 以 app.timezone 的日期区分, 超出时撤销本次计数
+	today := app.TimenowInTimezone().Format("20060102")
+	type counter struct {
+		key   string
+		limit int64
+	}
+	counters := []counter{{dailyKey(today, key), config.GetInt64("verifycode.daily_limit_per_key")}}
+	if len(clientIP) > 0 {
+		counters = append(counters, counter{dailyIPKey(today, clientIP), config.GetInt64("verifycode.daily_limit_per_ip")})
+	}
+	for _, c := range counters {
+		if c.limit <= 0 {
+			continue
+		}
+		counterKey := c.key
+		count := vc.Store.Increment(counterKey, 24*time.Hour)
+		// Redis 出错时 Increment 返回 0, 不限制发送, 也无需撤销
+		if count > 0 {
+			undo = append(undo, func() { vc.Store.Decrement(counterKey) })
+		}
+		if count > c.limit {
+			rollback()
+			return nil, ErrDailyLimitExceeded
+		}
+	}
+
+	// 3. 设置重发间隔, 并发的请求可能已先设置, 此时撤销计数
+	if interval > 0 {
+		if !vc.Store.SetIfNotExists(cooldownKey(key), "1", interval) {
+			rollback()
+			return nil, ErrSendTooFrequent
+		}
+		undo = append(undo, func() { vc.Store.Forget(cooldownKey(key)) })
+	}
+
+	return rollback, nil
+}
+
+// isDebugKey 非生产环境下, 具备特殊前缀的手机号和 email 后缀, 不做发送和校验
+func (vc *VerifyCode) isDebugKey(key string) bool {
+	return !app.IsProduction() && (strings.HasSuffix(key, config.GetString("verifycode.debug_email_suffix")) ||
+		strings.HasPrefix(key, config.GetString("verifycode.debug_phone_prefix")))
 }
 
 func (vc *VerifyCode) generateVerifyCode(key string) string {
@@ -102,6 +223,30 @@ func (vc *VerifyCode) generateVerifyCode(key string) string {
 
 	logger.DebugJSON("验证码", "生成验证码", map[string]string{key: code})
 
+	// 新的验证码, 重新计算输错次数
+	vc.Store.Forget(attemptsKey(key))
 	vc.Store.Set(key, code)
 	return code
 }
+
+// 以下辅助 key 以 ":" 开头, 与 KeyPrefix 之间保留分隔符
+
+// cooldownKey 重发间隔的存储 key
+func cooldownKey(key string) string {
+	return ":cooldown:" + key
+}
+
+// attemptsKey 输错次数的存储 key
+func attemptsKey(key string) string {
+	return ":attempts:" + key
+}
+
+// dailyKey key 每日发送次数的存储 key, date 格式为 20060102
+func dailyKey(date string, key string) string {
+	return ":daily:" + date + ":" + key
+}
+
+// dailyIPKey clientIP 每日发送次数的存储 key, date 格式为 20060102
+func dailyIPKey(date string, clientIP string) string {
+	return ":daily_ip:" + date + ":" + clientIP
+}
@@ -0,0 +1,127 @@
+package verifycode
+
+import (
+	"gohub/pkg/app"
+	"gohub/pkg/config"
+	"gohub/pkg/logger"
+	"gohub/pkg/redis"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	logger.Logger = zap.NewNop()
+	config.Set("app.name", "test")
+	config.Set("app.env", "production")
+	config.Set("queue.connection", "redis")
+	config.Set("queue.default", "default")
+	config.Set("verifycode.code_length", 6)
+	config.Set("verifycode.expire_time", 15)
+	os.Exit(m.Run())
+}
+
+// newTestVerifyCode 使用 miniredis 的 VerifyCode, 同时作为队列使用的 Redis
+func newTestVerifyCode(t *testing.T) (*VerifyCode, *miniredis.Miniredis) {
+	mr := miniredis.RunT(t)
+	redis.Redis = redis.NewClient(mr.Addr(), "", "", 0)
+	return &VerifyCode{Store: &RedisStore{RedisClient: redis.Redis, KeyPrefix: "test:verifycode"}}, mr
+}
+
+func TestSendPushesToQueue(t *testing.T) {
+	vc, mr := newTestVerifyCode(t)
+
+	// 推送到队列即返回, 不会在请求中调用服务商
+	if err := vc.SendSms("13800000000", "127.0.0.1"); err != nil {
+		t.Fatalf("SendSms() error = %v", err)
+	}
+	if err := vc.SendEmail("summer@example.com", "127.0.0.1"); err != nil {
+		t.Fatalf("SendEmail() error = %v", err)
+	}
+
+	jobs, err := mr.List("test:queue:default")
+	if err != nil || len(jobs) != 2 {
+		t.Fatalf("queued jobs = %v, %v, want 2 jobs", jobs, err)
+	}
+	if len(vc.Store.Get("13800000000", false)) != 6 || len(vc.Store.Get("summer@example.com", false)) != 6 {
+		t.Error("verify codes were not stored")
+	}
+}
+
+func TestThrottleDailyLimitDoesNotSetCooldown(t *testing.T) {
+	vc, mr := newTestVerifyCode(t)
+	config.Set("verifycode.resend_interval", 60)
+	config.Set("verifycode.daily_limit_per_key", 2)
+	config.Set("verifycode.daily_limit_per_ip", 0)
+	defer config.Set("verifycode.daily_limit_per_key", 0)
+
+	phone := "13800000001"
+	if err := vc.SendSms(phone, "127.0.0.1"); err != nil {
+		t.Fatalf("first SendSms() error = %v", err)
+	}
+	if !mr.Exists("test:verifycode:cooldown:" + phone) {
+		t.Errorf("cooldown key missing, keys = %v", mr.Keys())
+	}
+
+	// 重发间隔内
+	if err := vc.SendSms(phone, "127.0.0.1"); err != ErrSendTooFrequent {
+		t.Errorf("SendSms() within interval error = %v, want %v", err, ErrSendTooFrequent)
+	}
+
+	mr.FastForward(61 * time.Second)
+	if err := vc.SendSms(phone, "127.0.0.1"); err != nil {
+		t.Fatalf("second SendSms() error = %v", err)
+	}
+
+	// 超出每日次数, 之后的请求仍然是每日次数的错误, 不会设置重发间隔, 也不会继续累加计数
+	mr.FastForward(61 * time.Second)
+	for i := 0; i < 2; i++ {
+		if err := vc.SendSms(phone, "127.0.0.1"); err != ErrDailyLimitExceeded {
+			t.Errorf("SendSms() over daily limit error = %v, want %v", err, ErrDailyLimitExceeded)
+		}
+	}
+	if mr.Exists("test:verifycode:cooldown:" + phone) {
+		t.Error("cooldown set by a request rejected by the daily limit")
+	}
+	today := app.TimenowInTimezone().Format("20060102")
+	if count, _ := mr.Get("test:verifycode" + dailyKey(today, phone)); count != "2" {
+		t.Errorf("daily count = %q, want %q", count, "2")
+	}
+}
+
+func TestThrottleRollbackOnSendError(t *testing.T) {
+	vc, mr := newTestVerifyCode(t)
+	config.Set("verifycode.resend_interval", 60)
+	config.Set("verifycode.daily_limit_per_key", 5)
+	config.Set("verifycode.daily_limit_per_ip", 5)
+	defer config.Set("verifycode.daily_limit_per_key", 0)
+	defer config.Set("verifycode.daily_limit_per_ip", 0)
+
+	// sync 连接直接执行任务, 未配置短信驱动, 发送失败
+	config.Set("queue.connection", "sync")
+	defer config.Set("queue.connection", "redis")
+
+	phone := "13800000002"
+	if err := vc.SendSms(phone, "127.0.0.1"); err == nil {
+		t.Fatal("SendSms() without sms driver error = nil, want an error")
+	}
+
+	today := app.TimenowInTimezone().Format("20060102")
+	for _, key := range []string{dailyKey(today, phone), dailyIPKey(today, "127.0.0.1")} {
+		if count, _ := mr.Get("test:verifycode" + key); count != "0" {
+			t.Errorf("count of %s after failed send = %q, want %q", key, count, "0")
+		}
+	}
+	if mr.Exists("test:verifycode:cooldown:" + phone) {
+		t.Error("cooldown kept after failed send")
+	}
+
+	// 可以立即重试
+	config.Set("queue.connection", "redis")
+	if err := vc.SendSms(phone, "127.0.0.1"); err != nil {
+		t.Errorf("SendSms() retry error = %v", err)
+	}
+}
@@ -0,0 +1,9 @@
+// Package resources 编译进二进制文件的静态资源, 部署时无需携带 resources 目录
+package resources
+
+import "embed"
+
+// Mails 默认的邮件模板, 可被 mail.template_path 目录下的同名文件覆盖
+//
+//go:embed mails/*.html
+var Mails embed.FS
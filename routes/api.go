@@ -1,7 +1,9 @@
 package routes
 
 import (
+	controllers "gohub/app/http/controllers/api/v1"
 	"gohub/app/http/controllers/api/v1/auth"
+	"gohub/app/http/middlewares"
 
 	"github.com/gin-gonic/gin"
 )
@@ -10,18 +12,52 @@ import (
 func RegisterAPIRoutes(r *gin.Engine) {
 	// 测试一个 v1 的路由组, 所有的 v1 版本的路由都存放到这里
 	v1 := r.Group("v1")
+
+	// 全局限流中间件: 每小时限流. 这里是所有 API (根据 IP) 请求加起来
+	// 作为参考 Github API 每小时最多 60 个请求 (根据 IP)
+	// 测试时, 可以调高一点
+	v1.Use(middlewares.LimitIP("200-H"))
+
 	authGroup := v1.Group("/auth")
 	{
 		suc := new(auth.SignupController)
 		// 判断手机号是否被注册
-		authGroup.POST("/signup/phone/exist", suc.IsPhoneExist)
+		authGroup.POST("/signup/phone/exist", middlewares.GuestJWT(), middlewares.LimitPerRoute("60-H"), suc.IsPhoneExist)
 		// 判断邮箱是否被注册
-		authGroup.POST("/signup/email/exist", suc.IsEmailExist)
+		authGroup.POST("/signup/email/exist", middlewares.GuestJWT(), middlewares.LimitPerRoute("60-H"), suc.IsEmailExist)
+		// 使用手机号注册
+		authGroup.POST("/signup/using-phone", middlewares.GuestJWT(), suc.SignupUsingPhone)
+		// 使用邮箱注册
+		authGroup.POST("/signup/using-email", middlewares.GuestJWT(), suc.SignupUsingEmail)
 
 		// 发送验证码
 		vcc := new(auth.VerifyCodeController)
-		authGroup.POST("/verify-codes/captcha", vcc.ShowCaptcha)
-		authGroup.POST("/verify-codes/phone", vcc.SendUsingPhone)
-		authGroup.POST("/verify-codes/email", vcc.SendUsingEmail)
+		// 图片验证码
+		authGroup.POST("/verify-codes/captcha", middlewares.LimitPerRoute("50-H"), vcc.ShowCaptcha)
+		// 短信和邮件验证码会调用第三方服务, 限制得更严格一些
+		authGroup.POST("/verify-codes/phone", middlewares.LimitPerRoute("20-H"), vcc.SendUsingPhone)
+		authGroup.POST("/verify-codes/email", middlewares.LimitPerRoute("20-H"), vcc.SendUsingEmail)
+
+		lgc := new(auth.LoginController)
+		// 使用手机号, 短信验证码进行登录
+		authGroup.POST("/login/using-phone", middlewares.GuestJWT(), lgc.LoginByPhone)
+		// 支持手机号, Email 和 用户名
+		authGroup.POST("/login/using-password", middlewares.GuestJWT(), lgc.LoginByPassword)
+		// 刷新 Token, 过期的令牌也需要能够刷新, 故不使用 AuthJWT
+		authGroup.POST("/login/refresh-token", lgc.RefreshToken)
+
+		// 重置密码
+		pwc := new(auth.PasswordController)
+		authGroup.POST("/password-reset/using-phone", middlewares.GuestJWT(), pwc.ResetByPhone)
+		authGroup.POST("/password-reset/using-email", middlewares.GuestJWT(), pwc.ResetByEmail)
+	}
+
+	uc := new(controllers.UsersController)
+	// 获取当前用户
+	v1.GET("/user", middlewares.AuthJWT(), uc.CurrentUser)
+	// 用户列表仅登录用户可见
+	usersGroup := v1.Group("/users", middlewares.AuthJWT())
+	{
+		usersGroup.GET("", uc.Index)
 	}
 }